@@ -0,0 +1,134 @@
+package im
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	proto "github.com/aclisp/sims/proto/go"
+)
+
+// Transport is how HTTPClient receives the events
+type Transport int
+
+const (
+	// TransportWebsocket streams the events over a websocket
+	TransportWebsocket Transport = iota
+	// TransportSSE streams the events as server-sent events, for networks blocking websockets
+	TransportSSE
+	// TransportPoll polls the events by HTTP long-polling, for networks buffering streamed responses
+	TransportPoll
+)
+
+// pollWait is how long a poll asks the server to wait for events, which is bounded by the API gateway
+const pollWait = 25 * time.Second
+
+// sseEvents receives the events by Streamer.Events as server-sent events, until handle stops
+func (c *HTTPClient) sseEvents(ctx context.Context, req *proto.EventsRequest, timeout time.Duration, handle func(*proto.Event) bool) error {
+	eventsURL := fmt.Sprintf("http://%s/sims/streamer/events", c.Target)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	buf, _ := jsonMarshal(req)
+	r, err := http.NewRequestWithContext(ctx, "POST", eventsURL, bytes.NewReader(buf))
+	if err != nil {
+		return err
+	}
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Accept", "text/event-stream")
+	if c.Token != "" {
+		r.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	// the server pings on the stream, so it is dead if nothing is received in time
+	var silent int32
+	watchdog := time.AfterFunc(timeout, func() {
+		atomic.StoreInt32(&silent, 1)
+		cancel()
+	})
+	defer watchdog.Stop()
+	resp, err := c.httpClient.Do(r)
+	if err != nil {
+		return fmt.Errorf("node event source: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		data, _ := ioutil.ReadAll(resp.Body)
+		return errors.New(string(data))
+	}
+
+	var name string
+	var data []string
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(nil, 16<<20)
+	for scanner.Scan() {
+		watchdog.Reset(timeout)
+		line := scanner.Text()
+		switch {
+		case line == "":
+			// dispatch the lines so far
+			message, typ := []byte(strings.Join(data, "\n")), name
+			name, data = "", data[:0]
+			if len(message) == 0 {
+				continue
+			}
+			if typ == "error" {
+				return errors.New(string(message))
+			}
+			event := new(proto.Event)
+			if err := jsonUnmarshal(message, event); err != nil {
+				return err
+			}
+			if !handle(event) {
+				return nil
+			}
+		case strings.HasPrefix(line, ":"):
+			// comment
+		case strings.HasPrefix(line, "event:"):
+			name = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	if atomic.LoadInt32(&silent) == 1 {
+		return fmt.Errorf("node event source: nothing received in %v", timeout)
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	return scanner.Err()
+}
+
+// pollEvents receives the events by Streamer.Poll, until handle stops
+func (c *HTTPClient) pollEvents(ctx context.Context, req *proto.EventsRequest, timeout time.Duration, handle func(*proto.Event) bool) error {
+	pollURL := fmt.Sprintf("http://%s/sims/streamer/poll", c.Target)
+	cursor := req.ResumeFrom
+	for {
+		buf, _ := jsonMarshal(&proto.PollRequest{
+			Header: req.Header,
+			Cursor: cursor,
+			WaitMs: int64(pollWait / time.Millisecond),
+		})
+		res := new(proto.PollResponse)
+		pollCtx, cancel := context.WithTimeout(ctx, pollWait+timeout)
+		err := c.post(pollCtx, pollURL, "application/json", bytes.NewReader(buf), res)
+		cancel()
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("node poll: %w", err)
+		}
+		for _, event := range res.Events {
+			if !handle(event) {
+				return nil
+			}
+		}
+		cursor = res.Cursor
+	}
+}
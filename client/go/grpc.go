@@ -2,6 +2,7 @@ package im
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -10,16 +11,72 @@ import (
 
 	proto "github.com/aclisp/sims/proto/go"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
 )
 
 // GRPCClient TODO
 type GRPCClient struct {
-	Target string
-	UserID string
+	Target    string
+	UserID    string
+	DeviceID  string
+	UserAgent string
+	Topics    []string // subscribed on every connect
+	Token     string   // bearer token, if the server authenticates clients
+	// HeartbeatInterval is how often the server is asked to ping on the event stream, 0 for its default
+	HeartbeatInterval time.Duration
 
 	subscribeCtx context.Context
 	cancel       context.CancelFunc
 	conn         *grpc.ClientConn
+	seq          sequence
+	reconnect    *proto.Reconnect
+	hint         string // the node suggested by the last EVT_RECONNECT, instead of Target until it fails
+}
+
+func (c *GRPCClient) header() *proto.Header {
+	return &proto.Header{
+		UserId:    c.UserID,
+		DeviceId:  c.DeviceID,
+		UserAgent: c.UserAgent,
+	}
+}
+
+// bearer carries a bearer token in the metadata of each RPC
+type bearer string
+
+func (b bearer) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(b)}, nil
+}
+
+func (b bearer) RequireTransportSecurity() bool {
+	return false
+}
+
+// dialTimeout bounds dialing a node
+const dialTimeout = 10 * time.Second
+
+// dialOptions returns the options to dial a node, with the bearer token if not empty
+func dialOptions(token string) []grpc.DialOption {
+	opts := []grpc.DialOption{
+		grpc.WithInsecure(),
+		grpc.WithBlock(),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                30 * time.Second,
+			Timeout:             10 * time.Second,
+			PermitWithoutStream: true,
+		}),
+	}
+	if token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(bearer(token)))
+	}
+	return opts
+}
+
+// dial connects to a node within dialTimeout, since the node may be gone
+func dial(ctx context.Context, target, token string) (*grpc.ClientConn, error) {
+	ctx, cancel := context.WithTimeout(ctx, dialTimeout)
+	defer cancel()
+	return grpc.DialContext(ctx, target, dialOptions(token)...)
 }
 
 // Unicast TODO
@@ -44,22 +101,36 @@ func (c *GRPCClient) Subscribe(callback func(*proto.Event)) {
 	go func() {
 		for {
 			if err := c.SubscribeEvent(c.subscribeCtx, callback); err != nil {
+				if errors.Is(err, ErrKicked) {
+					log.Printf("subscribe stopped: %v", err)
+					break
+				}
 				log.Printf("subscribe event failure, retrying: %v", err)
 			}
 			if c.subscribeCtx.Err() != nil {
 				break
 			}
-			time.Sleep(5 * time.Second)
+			time.Sleep(retryDelay(&c.reconnect))
 		}
 		log.Println("subscribe done")
 	}()
 }
 
 // SubscribeEvent TODO
-func (c *GRPCClient) SubscribeEvent(ctx context.Context, callback func(*proto.Event)) error {
-	conn, err := grpc.DialContext(ctx, c.Target, grpc.WithInsecure(), grpc.WithBlock())
+func (c *GRPCClient) SubscribeEvent(ctx context.Context, callback func(*proto.Event)) (err error) {
+	// the node suggested by EVT_RECONNECT is left once it fails
+	target := c.Target
+	if c.hint != "" {
+		target = c.hint
+		defer func() {
+			if err != nil {
+				c.hint = ""
+			}
+		}()
+	}
+	conn, err := dial(ctx, target, c.Token)
 	if err != nil {
-		return fmt.Errorf("grpc dial: %w", err)
+		return fmt.Errorf("grpc dial %v: %w", target, err)
 	}
 	c.conn = conn
 	defer conn.Close()
@@ -67,41 +138,65 @@ func (c *GRPCClient) SubscribeEvent(ctx context.Context, callback func(*proto.Ev
 	hub := proto.NewHubClient(conn)
 	streamer := proto.NewStreamerClient(conn)
 
-	header := &proto.Header{
-		UserId: c.UserID,
-	}
-	if _, err := hub.Connect(ctx, &proto.ConnectRequest{
-		Header: header,
-	}); err != nil {
+	header := c.header()
+	connected, err := hub.Connect(ctx, &proto.ConnectRequest{
+		Header:              header,
+		HeartbeatIntervalMs: int64(c.HeartbeatInterval / time.Millisecond),
+	})
+	if err != nil {
 		return fmt.Errorf("node connect: %w", err)
 	}
+	interval, timeout := negotiated(connected)
+	for _, topic := range c.Topics {
+		if _, err := hub.Subscribe(ctx, &proto.SubscribeRequest{
+			Header: header,
+			Topic:  topic,
+		}); err != nil {
+			return fmt.Errorf("node subscribe %q: %w", topic, err)
+		}
+	}
 
-	stream, err := streamer.Events(context.Background(), &proto.EventsRequest{
-		Header: &proto.Header{
-			UserId:    c.UserID,
-			RequestId: strconv.FormatInt(time.Now().Unix(), 10),
-		},
+	eventsHeader := c.header()
+	eventsHeader.RequestId = strconv.FormatInt(time.Now().Unix(), 10)
+	streamCtx, cancelStream := context.WithCancel(context.Background())
+	defer cancelStream()
+	stream, err := streamer.Events(streamCtx, &proto.EventsRequest{
+		Header:     eventsHeader,
+		ResumeFrom: c.seq.resumeFrom(),
 	})
 	if err != nil {
 		return fmt.Errorf("node event setup: %w", err)
 	}
 
-	errHeartbeat := make(chan error, 1)
+	// the server pings on the stream, so it is dead if nothing is received in time
+	alive := time.AfterFunc(timeout, cancelStream)
+	defer alive.Stop()
+
+	errAck := make(chan error, 1)
 	go func() {
-		ticker := time.NewTicker(5 * time.Second)
-		for range ticker.C {
-			_, err := hub.Heartbeat(ctx, &proto.HeartbeatRequest{
-				Header: header,
-			})
-			if err != nil {
-				ticker.Stop()
-				errHeartbeat <- err
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-streamCtx.Done():
 				return
+			case <-ticker.C:
+			}
+			if seq := c.seq.acked(); seq > 0 {
+				_, err := hub.Ack(ctx, &proto.AckRequest{
+					Header: header,
+					Seq:    seq,
+				})
+				if err != nil {
+					errAck <- err
+					return
+				}
 			}
 		}
 	}()
 
 	errEvent := make(chan error, 1)
+	reconnect := make(chan *proto.Reconnect, 1)
 	go func() {
 		for {
 			event, err := stream.Recv()
@@ -109,11 +204,25 @@ func (c *GRPCClient) SubscribeEvent(ctx context.Context, callback func(*proto.Ev
 				break
 			}
 			if err != nil {
+				if streamCtx.Err() != nil && ctx.Err() == nil {
+					err = fmt.Errorf("no heartbeat in %v: %w", timeout, err)
+				}
 				errEvent <- err
 				return
 			}
+			alive.Reset(timeout)
+			if !c.seq.receive(event.Seq) {
+				continue
+			}
 			switch event.Type {
 			case proto.EventType_EVT_HEARTBEAT:
+			case proto.EventType_EVT_RECONNECT:
+				reconnect <- parseReconnect(event)
+				return
+			case proto.EventType_EVT_KICKED:
+				callback(event)
+				errEvent <- kickedError(event)
+				return
 			default:
 				callback(event)
 			}
@@ -122,12 +231,18 @@ func (c *GRPCClient) SubscribeEvent(ctx context.Context, callback func(*proto.Ev
 	}()
 
 	select {
-	case err := <-errHeartbeat:
-		return fmt.Errorf("node heartbeat: %w", err)
+	case err := <-errAck:
+		return fmt.Errorf("node ack: %w", err)
 	case err, ok := <-errEvent:
 		if ok {
 			return fmt.Errorf("node event stream: %w", err)
 		}
+	case r := <-reconnect:
+		// the node is shutting down: leave it cleanly, and move to the suggested one
+		hub.Ack(ctx, &proto.AckRequest{Header: header, Seq: c.seq.acked()})
+		hub.Disconnect(ctx, &proto.DisconnectRequest{Header: header})
+		c.reconnect = r
+		c.hint = r.Target
 	}
 	return nil //fmt.Errorf("node EOF: %w", io.EOF)
 }
@@ -145,9 +260,7 @@ func (c *GRPCClient) Close() error {
 	}
 
 	hub := proto.NewHubClient(c.conn)
-	header := &proto.Header{
-		UserId: c.UserID,
-	}
+	header := c.header()
 	if _, err := hub.Disconnect(context.TODO(), &proto.DisconnectRequest{
 		Header: header,
 	}); err != nil {
@@ -3,6 +3,7 @@ package im_test
 import (
 	"bytes"
 	"context"
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -13,6 +14,7 @@ import (
 
 	im "github.com/aclisp/sims/client/go"
 	proto "github.com/aclisp/sims/proto/go"
+	"google.golang.org/grpc"
 )
 
 func TestEventGRPC(t *testing.T) {
@@ -101,6 +103,45 @@ func TestCloseGRPC(t *testing.T) {
 	}
 }
 
+func TestKickGRPC(t *testing.T) {
+	bin := bin()
+
+	server := Command{Path: bin, Name: "server", Args: []string{"--server_address", "127.0.0.1:18080"}}
+	if err := server.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer server.Stop()
+
+	client := im.GRPCClient{
+		Target: "127.0.0.1:18080",
+		UserID: "homerhuang",
+	}
+
+	errSubscribe := make(chan error, 1)
+	go func() {
+		errSubscribe <- client.SubscribeEvent(context.Background(), func(e *proto.Event) {})
+	}()
+	time.Sleep(time.Second)
+
+	conn, err := grpc.Dial(client.Target, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if _, err := proto.NewAdminClient(conn).Kick(context.Background(), &proto.KickRequest{UserId: client.UserID, Reason: "test"}); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-errSubscribe:
+		if !errors.Is(err, im.ErrKicked) {
+			t.Fatalf("subscribe returns %v, want %v", err, im.ErrKicked)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscribe goes on after kicked")
+	}
+}
+
 // bin returns the project `bin` dir path; must be called from TestXXX
 func bin() string {
 	_, filename, _, _ := runtime.Caller(1)
@@ -0,0 +1,23 @@
+package im
+
+import (
+	"time"
+
+	proto "github.com/aclisp/sims/proto/go"
+)
+
+// defaultHeartbeatInterval is assumed when the server does not tell the negotiated one
+const defaultHeartbeatInterval = 5 * time.Second
+
+// negotiated returns how often the server pings on the event stream, and after how long without any event it is considered dead
+func negotiated(res *proto.ConnectResponse) (interval, timeout time.Duration) {
+	interval = time.Duration(res.GetHeartbeatIntervalMs()) * time.Millisecond
+	if interval <= 0 {
+		interval = defaultHeartbeatInterval
+	}
+	timeout = time.Duration(res.GetInactivityTimeoutMs()) * time.Millisecond
+	if timeout <= 0 {
+		timeout = 2 * interval
+	}
+	return interval, timeout
+}
@@ -17,8 +17,12 @@ import (
 	"github.com/gobwas/ws/wsutil"
 	"github.com/golang/protobuf/jsonpb"
 	pb "github.com/golang/protobuf/proto"
+	"github.com/micro/go-micro/v2/util/websocket"
 )
 
+// ProtoSubprotocol is the websocket subprotocol of length-delimited protobuf messages
+const ProtoSubprotocol = "sims.v1.proto"
+
 var (
 	jsonMarshaler = jsonpb.Marshaler{
 		OrigName: true,
@@ -28,13 +32,35 @@ var (
 
 // HTTPClient TODO
 type HTTPClient struct {
-	Target string
-	UserID string
+	Target    string
+	UserID    string
+	DeviceID  string
+	UserAgent string
+	Topics    []string // subscribed on every connect
+	Token     string   // bearer token, if the server authenticates clients
+	// HeartbeatInterval is how often the server is asked to ping on the event stream, 0 for its default
+	HeartbeatInterval time.Duration
+	// Transport is how the events are received, over a websocket by default
+	Transport Transport
+	// Protobuf exchanges length-delimited protobuf rather than JSON on the event websocket, by the ProtoSubprotocol
+	Protobuf bool
+	// Deflate offers permessage-deflate on the event websocket
+	Deflate bool
 
 	subscribeCtx context.Context
 	cancel       context.CancelFunc
 	httpClient   http.Client
 	wsDialer     ws.Dialer
+	seq          sequence
+	reconnect    *proto.Reconnect
+}
+
+func (c *HTTPClient) header() *proto.Header {
+	return &proto.Header{
+		UserId:    c.UserID,
+		DeviceId:  c.DeviceID,
+		UserAgent: c.UserAgent,
+	}
 }
 
 // EventHandler handles server-sent events
@@ -60,12 +86,16 @@ func jsonUnmarshal(data []byte, m pb.Message) error {
 	return jsonUnmarshaler.Unmarshal(bytes.NewReader(data), m)
 }
 
-func (c *HTTPClient) post(ctx context.Context, url, contentType string, body io.Reader) error {
+// post sends body to url, and decodes the JSON response into res unless it is nil
+func (c *HTTPClient) post(ctx context.Context, url, contentType string, body io.Reader, res pb.Message) error {
 	req, err := http.NewRequestWithContext(ctx, "POST", url, body)
 	if err != nil {
 		return err
 	}
 	req.Header.Set("Content-Type", contentType)
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return err
@@ -78,6 +108,9 @@ func (c *HTTPClient) post(ctx context.Context, url, contentType string, body io.
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
 		return errors.New(string(data))
 	}
+	if res != nil {
+		return jsonUnmarshal(data, res)
+	}
 	return nil
 }
 
@@ -92,7 +125,7 @@ func (c *HTTPClient) Unicast(toUserID, text string) error {
 	})
 	unicastURL := fmt.Sprintf("http://%s/sims/publisher/unicast", c.Target)
 	contentJSON := "application/json"
-	if err := c.post(context.TODO(), unicastURL, contentJSON, bytes.NewReader(buf)); err != nil {
+	if err := c.post(context.TODO(), unicastURL, contentJSON, bytes.NewReader(buf), nil); err != nil {
 		return fmt.Errorf("sims unicast: %w", err)
 	}
 	return nil
@@ -104,12 +137,16 @@ func (c *HTTPClient) Subscribe(h EventHandler) {
 	go func() {
 		for {
 			if err := c.SubscribeEvent(c.subscribeCtx, h); err != nil {
+				if errors.Is(err, ErrKicked) {
+					log.Printf("subscribe stopped: %v", err)
+					break
+				}
 				log.Printf("subscribe event failure, retrying: %v", err)
 			}
 			if c.subscribeCtx.Err() != nil {
 				break
 			}
-			time.Sleep(5 * time.Second)
+			time.Sleep(retryDelay(&c.reconnect))
 		}
 		log.Println("subscribe done")
 	}()
@@ -120,90 +157,212 @@ func (c *HTTPClient) SubscribeEvent(ctx context.Context, h EventHandler) error {
 	var (
 		contentJSON  = "application/json"
 		connectURL   = fmt.Sprintf("http://%s/sims/hub/connect", c.Target)
-		heartbeatURL = fmt.Sprintf("http://%s/sims/hub/heartbeat", c.Target)
-		eventsURL    = fmt.Sprintf("ws://%s/sims/streamer/events", c.Target)
-		header       = &proto.Header{
-			UserId: c.UserID,
+		ackURL       = fmt.Sprintf("http://%s/sims/hub/ack", c.Target)
+		subscribeURL = fmt.Sprintf("http://%s/sims/hub/subscribe", c.Target)
+		header       = c.header()
+		connectReq   = &proto.ConnectRequest{
+			Header:              header,
+			HeartbeatIntervalMs: int64(c.HeartbeatInterval / time.Millisecond),
 		}
-		connectReq = &proto.ConnectRequest{Header: header}
+		connectRes = new(proto.ConnectResponse)
 		eventsReq  = &proto.EventsRequest{Header: &proto.Header{
 			UserId:    c.UserID,
+			DeviceId:  c.DeviceID,
+			UserAgent: c.UserAgent,
 			RequestId: strconv.FormatInt(time.Now().Unix(), 10),
-		}}
-		heartbeatReq = &proto.HeartbeatRequest{Header: header}
-		cancel       context.CancelFunc
+		}, ResumeFrom: c.seq.resumeFrom()}
+		cancel context.CancelFunc
 	)
 
 	ctx, cancel = context.WithCancel(ctx)
 	defer cancel()
 
 	buf, _ := jsonMarshal(connectReq)
-	if err := c.post(ctx, connectURL, contentJSON, bytes.NewReader(buf)); err != nil {
+	if err := c.post(ctx, connectURL, contentJSON, bytes.NewReader(buf), connectRes); err != nil {
 		return fmt.Errorf("node connect: %w", err)
 	}
-
-	conn, _, _, err := c.wsDialer.Dial(ctx, eventsURL)
-	if err != nil {
-		return fmt.Errorf("node websocket dial: %w", err)
+	interval, timeout := negotiated(connectRes)
+	for _, topic := range c.Topics {
+		buf, _ := jsonMarshal(&proto.SubscribeRequest{Header: header, Topic: topic})
+		if err := c.post(ctx, subscribeURL, contentJSON, bytes.NewReader(buf), nil); err != nil {
+			return fmt.Errorf("node subscribe %q: %w", topic, err)
+		}
 	}
-	defer conn.Close()
 
-	buf, _ = jsonMarshal(eventsReq)
-	if err := wsutil.WriteClientText(conn, buf); err != nil {
-		return fmt.Errorf("node websocket send: %w", err)
+	errAck := make(chan error, 1)
+	if c.Transport != TransportPoll {
+		// polls acknowledge by their cursor
+		go func() {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+				if seq := c.seq.acked(); seq > 0 {
+					buf, _ := jsonMarshal(&proto.AckRequest{Header: header, Seq: seq})
+					if err := c.post(ctx, ackURL, contentJSON, bytes.NewReader(buf), nil); err != nil {
+						errAck <- err
+						return
+					}
+				}
+			}
+		}()
 	}
 
-	errHeartbeat := make(chan error, 1)
-	go func() {
-		ticker := time.NewTicker(5 * time.Second)
-		for range ticker.C {
-			buf, _ := jsonMarshal(heartbeatReq)
-			if err := c.post(ctx, heartbeatURL, contentJSON, bytes.NewReader(buf)); err != nil {
-				ticker.Stop()
-				errHeartbeat <- err
-				return
-			}
+	var reconnect *proto.Reconnect
+	var kicked error
+	// handle handles an event received, and reports whether to receive more
+	handle := func(event *proto.Event) bool {
+		if !c.seq.receive(event.Seq) {
+			return true
 		}
-	}()
-
+		switch event.Type {
+		case proto.EventType_EVT_HEARTBEAT:
+		case proto.EventType_EVT_RECONNECT:
+			reconnect = parseReconnect(event)
+			return false
+		case proto.EventType_EVT_KICKED:
+			h.OnEvent(event)
+			kicked = kickedError(event)
+			return false
+		default:
+			h.OnEvent(event)
+		}
+		return true
+	}
 	errEvent := make(chan error, 1)
 	go func() {
-		for {
-			data, err := wsutil.ReadServerText(conn)
-			if err == io.EOF {
-				break
-			}
-			if err != nil {
-				errEvent <- err
-				return
-			}
-
-			event := new(proto.Event)
-			if err := jsonUnmarshal(data, event); err != nil {
-				errEvent <- err
-				return
-			}
-
-			switch event.Type {
-			case proto.EventType_EVT_HEARTBEAT:
-			default:
-				h.OnEvent(event)
-			}
+		var err error
+		switch c.Transport {
+		case TransportSSE:
+			err = c.sseEvents(ctx, eventsReq, timeout, handle)
+		case TransportPoll:
+			err = c.pollEvents(ctx, eventsReq, timeout, handle)
+		default:
+			err = c.websocketEvents(ctx, eventsReq, timeout, handle)
+		}
+		if err != nil {
+			errEvent <- err
+			return
 		}
 		close(errEvent)
 	}()
 
 	select {
-	case err := <-errHeartbeat:
-		return fmt.Errorf("node heartbeat: %w", err)
+	case err := <-errAck:
+		return fmt.Errorf("node ack: %w", err)
 	case err, ok := <-errEvent:
 		if ok {
 			return fmt.Errorf("node event stream: %w", err)
 		}
+		if kicked != nil {
+			return kicked
+		}
+		if reconnect != nil {
+			// the node is shutting down, the API gateway routes the next subscription to another one
+			c.reconnect = reconnect
+		}
 	}
 	return nil //fmt.Errorf("node EOF: %w", io.EOF)
 }
 
+// websocketEvents receives the events by Streamer.Events over a websocket, until handle stops
+func (c *HTTPClient) websocketEvents(ctx context.Context, req *proto.EventsRequest, timeout time.Duration, handle func(*proto.Event) bool) error {
+	eventsURL := fmt.Sprintf("ws://%s/sims/streamer/events", c.Target)
+	if c.Token != "" {
+		c.wsDialer.Header = ws.HandshakeHeaderHTTP(http.Header{"Authorization": {"Bearer " + c.Token}})
+	}
+	c.wsDialer.Protocols, c.wsDialer.Extensions = nil, nil
+	if c.Protobuf {
+		c.wsDialer.Protocols = []string{ProtoSubprotocol}
+	}
+	if c.Deflate {
+		c.wsDialer.Extensions = append(c.wsDialer.Extensions, websocket.DeflateOffer)
+	}
+	conn, br, hs, err := c.wsDialer.Dial(ctx, eventsURL)
+	if err != nil {
+		return fmt.Errorf("node websocket dial: %w", err)
+	}
+	defer conn.Close()
+	if c.Protobuf && hs.Protocol != ProtoSubprotocol {
+		return fmt.Errorf("node websocket dial: subprotocol %v not supported", ProtoSubprotocol)
+	}
+	var source io.Reader = conn
+	if br != nil {
+		// the frames already received with the handshake
+		source = br
+		defer ws.PutReader(br)
+	}
+	state := ws.StateClientSide
+	deflate := websocket.Deflated(hs.Extensions)
+	if deflate {
+		state |= ws.StateExtended
+	}
+
+	op := ws.OpText
+	var buf []byte
+	if c.Protobuf {
+		op = ws.OpBinary
+		buf, _ = pb.Marshal(req)
+		buf = websocket.AppendDelimited(nil, buf)
+	} else {
+		buf, _ = jsonMarshal(req)
+	}
+	if err := websocket.WriteMessage(conn, ws.StateClientSide, op, buf, deflate); err != nil {
+		return fmt.Errorf("node websocket send: %w", err)
+	}
+
+	rd := &wsutil.Reader{Source: source, State: state}
+	control := wsutil.ControlFrameHandler(conn, ws.StateClientSide)
+	for {
+		// the server pings on the stream, so it is dead if nothing is received in time
+		conn.SetReadDeadline(time.Now().Add(timeout))
+		data, _, err := websocket.ReadMessage(rd, control)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		events, err := c.decodeEvents(data)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			if !handle(event) {
+				return nil
+			}
+		}
+	}
+}
+
+// decodeEvents decodes the events in a message of the event stream
+func (c *HTTPClient) decodeEvents(data []byte) ([]*proto.Event, error) {
+	if !c.Protobuf {
+		event := new(proto.Event)
+		if err := jsonUnmarshal(data, event); err != nil {
+			return nil, err
+		}
+		return []*proto.Event{event}, nil
+	}
+	msgs, err := websocket.SplitDelimited(data)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]*proto.Event, len(msgs))
+	for i, msg := range msgs {
+		events[i] = new(proto.Event)
+		if err := pb.Unmarshal(msg, events[i]); err != nil {
+			return nil, err
+		}
+	}
+	return events, nil
+}
+
 // Close TODO
 func (c *HTTPClient) Close() error {
 	defer func() {
@@ -213,13 +372,11 @@ func (c *HTTPClient) Close() error {
 	}()
 
 	buf, _ := jsonMarshal(&proto.DisconnectRequest{
-		Header: &proto.Header{
-			UserId: c.UserID,
-		},
+		Header: c.header(),
 	})
 	disconnectURL := fmt.Sprintf("http://%s/sims/hub/disconnect", c.Target)
 	contentJSON := "application/json"
-	if err := c.post(context.TODO(), disconnectURL, contentJSON, bytes.NewReader(buf)); err != nil {
+	if err := c.post(context.TODO(), disconnectURL, contentJSON, bytes.NewReader(buf), nil); err != nil {
 		return fmt.Errorf("node disconnect: %w", err)
 	}
 	return nil
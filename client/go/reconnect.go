@@ -0,0 +1,45 @@
+package im
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	proto "github.com/aclisp/sims/proto/go"
+)
+
+// retryInterval is the wait before subscribing again after a failure
+const retryInterval = 5 * time.Second
+
+// ErrKicked is returned by SubscribeEvent once the client is kicked or banned, after which Subscribe stops
+var ErrKicked = errors.New("kicked")
+
+// kickedError returns ErrKicked with the reason of an EVT_KICKED event
+func kickedError(event *proto.Event) error {
+	kicked := new(proto.Kicked)
+	jsonUnmarshal(event.Data, kicked)
+	if kicked.BannedUntil > 0 {
+		until := time.Unix(0, kicked.BannedUntil*int64(time.Millisecond)).UTC().Format(time.RFC3339)
+		return fmt.Errorf("%w, banned until %v: %s", ErrKicked, until, kicked.Reason)
+	}
+	return fmt.Errorf("%w: %s", ErrKicked, kicked.Reason)
+}
+
+// parseReconnect decodes the Reconnect hint of an EVT_RECONNECT event
+func parseReconnect(event *proto.Event) *proto.Reconnect {
+	reconnect := new(proto.Reconnect)
+	if err := jsonUnmarshal(event.Data, reconnect); err != nil {
+		return new(proto.Reconnect)
+	}
+	return reconnect
+}
+
+// retryDelay returns the wait before subscribing again, which is suggested by the last EVT_RECONNECT if any
+func retryDelay(reconnect **proto.Reconnect) time.Duration {
+	if *reconnect == nil {
+		return retryInterval
+	}
+	delay := time.Duration((*reconnect).BackoffMs) * time.Millisecond
+	*reconnect = nil
+	return delay
+}
@@ -0,0 +1,42 @@
+package im
+
+import "sync"
+
+// sequence tracks the events received from the server, for acknowledging and resuming
+type sequence struct {
+	lock    sync.Mutex
+	last    uint64 // the last sequence received
+	started bool   // an event stream has been set up before
+}
+
+// resumeFrom returns the sequence from which the next event stream redelivers
+func (s *sequence) resumeFrom() uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !s.started {
+		s.started = true
+		return 0
+	}
+	return s.last + 1
+}
+
+// receive tells if the event of seq is new, i.e. not a redelivered duplicate
+func (s *sequence) receive(seq uint64) bool {
+	if seq == 0 {
+		return true
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if seq <= s.last {
+		return false
+	}
+	s.last = seq
+	return true
+}
+
+// acked returns the last sequence received, which should be acknowledged
+func (s *sequence) acked() uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.last
+}
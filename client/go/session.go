@@ -0,0 +1,295 @@
+package im
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	proto "github.com/aclisp/sims/proto/go"
+	"go.uber.org/atomic"
+)
+
+// resultTimeout is the wait for the result of a frame sent in a session
+const resultTimeout = 5 * time.Second
+
+// SessionClient holds one Streamer.Session per device, which carries the events, acks and sending
+type SessionClient struct {
+	Target    string
+	UserID    string
+	DeviceID  string
+	UserAgent string
+	Topics    []string // subscribed on every connect
+	Token     string   // bearer token, if the server authenticates clients
+	// HeartbeatInterval is how often the server is asked to ping on the session, 0 for its default
+	HeartbeatInterval time.Duration
+
+	subscribeCtx context.Context
+	cancel       context.CancelFunc
+	seq          sequence
+	reconnect    *proto.Reconnect
+	hint         string // the node suggested by the last EVT_RECONNECT, instead of Target until it fails
+	nextID       atomic.Uint64
+
+	lock    sync.Mutex
+	stream  proto.Streamer_SessionClient
+	pending map[string]chan *proto.SessionResult
+}
+
+func (c *SessionClient) header() *proto.Header {
+	return &proto.Header{
+		UserId:    c.UserID,
+		DeviceId:  c.DeviceID,
+		UserAgent: c.UserAgent,
+		RequestId: strconv.FormatInt(time.Now().Unix(), 10),
+	}
+}
+
+// send sends a frame on the current session
+func (c *SessionClient) send(req *proto.SessionRequest) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.stream == nil {
+		return errors.New("no session")
+	}
+	return c.stream.Send(req)
+}
+
+// call sends a frame on the current session, and waits for its result
+func (c *SessionClient) call(ctx context.Context, req *proto.SessionRequest) error {
+	req.Id = strconv.FormatUint(c.nextID.Inc(), 10)
+	result := make(chan *proto.SessionResult, 1)
+	c.lock.Lock()
+	if c.pending == nil {
+		c.pending = make(map[string]chan *proto.SessionResult)
+	}
+	c.pending[req.Id] = result
+	c.lock.Unlock()
+	defer func() {
+		c.lock.Lock()
+		delete(c.pending, req.Id)
+		c.lock.Unlock()
+	}()
+
+	if err := c.send(req); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, resultTimeout)
+	defer cancel()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case r := <-result:
+		if r.Error != "" {
+			return fmt.Errorf("%v: %s", r.Errcode, r.Error)
+		}
+		return nil
+	}
+}
+
+// done delivers the result of a frame to its caller
+func (c *SessionClient) done(id string, result *proto.SessionResult) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if ch, ok := c.pending[id]; ok {
+		ch <- result
+	}
+}
+
+// Unicast TODO
+func (c *SessionClient) Unicast(toUserID, text string) error {
+	err := c.call(context.TODO(), &proto.SessionRequest{
+		Frame: &proto.SessionRequest_Send{Send: &proto.UnicastRequest{
+			UserId: toUserID,
+			Event: &proto.Event{
+				Type: proto.EventType_EVT_TEXT,
+				Data: []byte(text),
+			},
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("sims unicast: %w", err)
+	}
+	return nil
+}
+
+// Subscribe TODO
+func (c *SessionClient) Subscribe(callback func(*proto.Event)) {
+	c.subscribeCtx, c.cancel = context.WithCancel(context.Background())
+	go func() {
+		for {
+			if err := c.SubscribeEvent(c.subscribeCtx, callback); err != nil {
+				if errors.Is(err, ErrKicked) {
+					log.Printf("subscribe stopped: %v", err)
+					break
+				}
+				log.Printf("subscribe event failure, retrying: %v", err)
+			}
+			if c.subscribeCtx.Err() != nil {
+				break
+			}
+			time.Sleep(retryDelay(&c.reconnect))
+		}
+		log.Println("subscribe done")
+	}()
+}
+
+// SubscribeEvent TODO
+func (c *SessionClient) SubscribeEvent(ctx context.Context, callback func(*proto.Event)) (err error) {
+	// the node suggested by EVT_RECONNECT is left once it fails
+	target := c.Target
+	if c.hint != "" {
+		target = c.hint
+		defer func() {
+			if err != nil {
+				c.hint = ""
+			}
+		}()
+	}
+	conn, err := dial(ctx, target, c.Token)
+	if err != nil {
+		return fmt.Errorf("grpc dial %v: %w", target, err)
+	}
+	defer conn.Close()
+
+	streamCtx, cancelStream := context.WithCancel(ctx)
+	defer cancelStream()
+	stream, err := proto.NewStreamerClient(conn).Session(streamCtx)
+	if err != nil {
+		return fmt.Errorf("node session setup: %w", err)
+	}
+	if err := stream.Send(&proto.SessionRequest{
+		Frame: &proto.SessionRequest_Connect{Connect: &proto.SessionConnect{
+			Header:              c.header(),
+			HeartbeatIntervalMs: int64(c.HeartbeatInterval / time.Millisecond),
+			ResumeFrom:          c.seq.resumeFrom(),
+			Topic:               c.Topics,
+		}},
+	}); err != nil {
+		return fmt.Errorf("node connect: %w", err)
+	}
+	res, err := stream.Recv()
+	if err != nil {
+		return fmt.Errorf("node connect: %w", err)
+	}
+	connected := res.GetConnected()
+	if connected == nil {
+		return fmt.Errorf("node connect: unexpected frame %v", res)
+	}
+	interval, timeout := negotiated(connected)
+
+	c.lock.Lock()
+	c.stream = stream
+	c.lock.Unlock()
+	defer func() {
+		c.lock.Lock()
+		c.stream = nil
+		c.lock.Unlock()
+	}()
+
+	// the server pings on the session, so it is dead if nothing is received in time
+	alive := time.AfterFunc(timeout, cancelStream)
+	defer alive.Stop()
+
+	errAck := make(chan error, 1)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-streamCtx.Done():
+				return
+			case <-ticker.C:
+			}
+			if seq := c.seq.acked(); seq > 0 {
+				if err := c.send(&proto.SessionRequest{
+					Frame: &proto.SessionRequest_Ack{Ack: &proto.AckRequest{Seq: seq}},
+				}); err != nil {
+					errAck <- err
+					return
+				}
+			}
+		}
+	}()
+
+	errEvent := make(chan error, 1)
+	reconnect := make(chan *proto.Reconnect, 1)
+	go func() {
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				if streamCtx.Err() != nil && ctx.Err() == nil {
+					err = fmt.Errorf("no heartbeat in %v: %w", timeout, err)
+				}
+				errEvent <- err
+				return
+			}
+			alive.Reset(timeout)
+			if result := res.GetResult(); result != nil {
+				c.done(res.Id, result)
+				continue
+			}
+			event := res.GetEvent()
+			if event == nil || !c.seq.receive(event.Seq) {
+				continue
+			}
+			switch event.Type {
+			case proto.EventType_EVT_HEARTBEAT:
+			case proto.EventType_EVT_RECONNECT:
+				reconnect <- parseReconnect(event)
+				return
+			case proto.EventType_EVT_KICKED:
+				callback(event)
+				errEvent <- kickedError(event)
+				return
+			default:
+				callback(event)
+			}
+		}
+		close(errEvent)
+	}()
+
+	select {
+	case err := <-errAck:
+		return fmt.Errorf("node ack: %w", err)
+	case err, ok := <-errEvent:
+		if ok {
+			return fmt.Errorf("node session: %w", err)
+		}
+	case r := <-reconnect:
+		// the node is shutting down: leave it cleanly, and move to the suggested one
+		c.send(&proto.SessionRequest{
+			Frame: &proto.SessionRequest_Ack{Ack: &proto.AckRequest{Seq: c.seq.acked()}},
+		})
+		c.send(&proto.SessionRequest{
+			Frame: &proto.SessionRequest_Disconnect{Disconnect: &proto.DisconnectRequest{}},
+		})
+		c.reconnect = r
+		c.hint = r.Target
+	}
+	return nil
+}
+
+// Close TODO
+func (c *SessionClient) Close() error {
+	defer func() {
+		if c.cancel != nil {
+			c.cancel()
+		}
+	}()
+
+	err := c.call(context.TODO(), &proto.SessionRequest{
+		Frame: &proto.SessionRequest_Disconnect{Disconnect: &proto.DisconnectRequest{}},
+	})
+	if err != nil {
+		return fmt.Errorf("node disconnect: %w", err)
+	}
+	return nil
+}
@@ -1,8 +1,27 @@
+import {PROTO_SUBPROTOCOL, encodeEventsRequest, decodeEvents} from './proto';
+
 interface Event {
-    type: 'EVT_TEXT' | 'EVT_JSON';
-    data: string;
+    type: 'EVT_HEARTBEAT' | 'EVT_TEXT' | 'EVT_JSON' | 'EVT_PROTOBUF' | 'EVT_BINARY' | 'EVT_RECONNECT' | 'EVT_KICKED';
+    // an object if the server knows its type_url, or else decoded from base64.
+    // With protobuf, the bytes of EVT_PROTOBUF and EVT_BINARY, or else decoded from UTF-8
+    data: string | object | Uint8Array;
+    seq?: string; // uint64 is encoded as string in JSON
+    timestamp?: string;
+    type_url?: string;
+}
+
+// jsonEvent decodes the data of an event in JSON
+function jsonEvent(event: Event): Event {
+    if (typeof event.data === 'string') event.data = atob(event.data);
+    return event;
 }
 
+// how the events are received. 'sse' and 'poll' are fallbacks for networks blocking websockets,
+// and a poll returns the events in batches, for networks buffering streamed responses too
+export type Transport = 'websocket' | 'sse' | 'poll';
+
+const POLL_WAIT = 25000; // how long a poll waits for events in milliseconds, bounded by the gateway
+
 interface EventHandler {
     (event: Event): void;
 }
@@ -14,11 +33,21 @@ interface ErrorHandler {
 export default class EventStream {
     readonly target: string;
     readonly userId: string;
+    readonly deviceId: string;
+    heartbeatInterval = 0; // how often the server is asked to ping in milliseconds, 0 for its default
+    // exchange protobuf rather than JSON on the event stream, by the sims.v1.proto subprotocol.
+    // Browsers compress it by permessage-deflate if the gateway agrees
+    protobuf = false;
+    transport: Transport = 'websocket';
     private closed: boolean;
+    private lastSeq = 0;
+    private started = false;
+    private retryAfter = 5000; // the backoff of the next retry in milliseconds
 
-    constructor(target: string, userId: string) {
+    constructor(target: string, userId: string, deviceId = '') {
         this.target = target;
         this.userId = userId;
+        this.deviceId = deviceId;
         this.closed = true;
     }
 
@@ -27,9 +56,11 @@ export default class EventStream {
         const onError = (err: Error) => {
             if (!this.closed) {
                 console.log(`subscribe event failure, retrying: ${err}`);
+                const retryAfter = this.retryAfter;
+                this.retryAfter = 5000;
                 window.setTimeout(() => {
                     this.subscribeEvent(onEvent, onError);
-                }, 5000);
+                }, retryAfter);
             }
         };
         this.subscribeEvent(onEvent, onError);
@@ -37,30 +68,201 @@ export default class EventStream {
 
     subscribeEvent(onEvent: EventHandler, onError: ErrorHandler) {
         const connectUrl = `http://${this.target}/sims/hub/connect`;
-        const heartbeatUrl = `http://${this.target}/sims/hub/heartbeat`;
+        const ackUrl = `http://${this.target}/sims/hub/ack`;
+        const header = {
+            user_id: this.userId,
+            device_id: this.deviceId,
+            user_agent: navigator.userAgent,
+        };
         const body = JSON.stringify({
-            header: {
-                user_id: this.userId,
-            },
+            header: header,
+            heartbeat_interval_ms: this.heartbeatInterval.toString(),
         });
-        let ws: WebSocket;
+        let stop = () => {}; // closes the event stream
+        let ended = false;
         let beat = 0;
+        let watchdog = 0;
+        let interval = 5000;
+        let timeout = 10000;
 
-        function heartbeat() {
+        // end stops the heartbeats and reports err once
+        const end = (err: Error) => {
+            if (ended) return;
+            ended = true;
+            window.clearInterval(beat);
+            window.clearTimeout(watchdog);
+            console.debug(err.message);
+            onError(err);
+        };
+
+        // the server pings on the event stream, so it is dead if nothing is received in time
+        const alive = () => {
+            window.clearTimeout(watchdog);
+            watchdog = window.setTimeout(() => {
+                stop();
+                end(new Error(`event stream: nothing received in ${timeout}ms`));
+            }, timeout);
+        };
+
+        const ack = () => {
+            if (this.lastSeq === 0) return;
             window
-                .fetch(heartbeatUrl, {method: 'POST', body: body})
+                .fetch(ackUrl, {
+                    method: 'POST',
+                    body: JSON.stringify({
+                        header: header,
+                        seq: this.lastSeq.toString(),
+                    }),
+                })
                 .then(response => {
-                    if (!response.ok) {
-                        throw new Error(`event stream heartbeat: ${response.status}`);
+                    if (response && !response.ok) {
+                        throw new Error(`event stream ack: ${response.status}`);
                     }
                 })
                 .catch(err => {
-                    if (ws) {
-                        ws.close();
-                    }
-                    onError(err);
+                    stop();
+                    end(err);
                 });
-        }
+        };
+
+        // handle passes the events to onEvent, or else reconnects as the node asks
+        const handle = (events: Event[]) => {
+            for (const event of events) {
+                const seq = Number(event.seq || 0);
+                if (seq > 0) {
+                    if (seq <= this.lastSeq) continue; // redelivered duplicate
+                    this.lastSeq = seq;
+                }
+                if (event.type === 'EVT_HEARTBEAT') continue;
+                if (event.type === 'EVT_RECONNECT') {
+                    // the node is shutting down, reconnect after the suggested backoff
+                    this.retryAfter = Number(JSON.parse(event.data as string).backoff_ms || 0);
+                    stop();
+                    end(new Error('event stream: the node asks to reconnect'));
+                    return;
+                }
+                if (event.type === 'EVT_KICKED') {
+                    // kicked or banned, so stop subscribing
+                    onEvent(event);
+                    this.closed = true;
+                    stop();
+                    end(new Error('event stream: kicked'));
+                    return;
+                }
+                onEvent(event);
+            }
+        };
+
+        const resume = () => {
+            const resumeFrom = this.started ? this.lastSeq + 1 : 0;
+            this.started = true;
+            return resumeFrom;
+        };
+        const requestId = () => Math.floor(Date.now() / 1000).toString();
+
+        const websocket = () => {
+            const ws = new WebSocket(
+                `ws://${this.target}/sims/streamer/events`,
+                this.protobuf ? [PROTO_SUBPROTOCOL] : [],
+            );
+            stop = () => ws.close();
+            ws.binaryType = 'arraybuffer';
+            ws.onopen = () => {
+                const request = {header: {...header, request_id: requestId()}, resume_from: resume()};
+                if (this.protobuf) {
+                    ws.send(encodeEventsRequest(request));
+                } else {
+                    ws.send(JSON.stringify({...request, resume_from: request.resume_from.toString()}));
+                }
+                beat = window.setInterval(ack, interval);
+                alive();
+            };
+            ws.onmessage = ev => {
+                alive();
+                if (ev.data instanceof ArrayBuffer) {
+                    handle(
+                        decodeEvents(new Uint8Array(ev.data)).map(e => {
+                            const event = e as Event;
+                            if (e.type !== 'EVT_PROTOBUF' && e.type !== 'EVT_BINARY') {
+                                event.data = new TextDecoder().decode(e.data);
+                            }
+                            return event;
+                        }),
+                    );
+                } else if (ev.data !== '{}') {
+                    handle([jsonEvent(JSON.parse(ev.data))]);
+                }
+            };
+            ws.onclose = ev => {
+                end(new Error(`event stream websocket closed: ${ev.code} (clean=${ev.wasClean})`));
+            };
+            ws.onerror = ev => {
+                end(new Error(`event stream websocket error: ${ev}`));
+            };
+        };
+
+        // eventSource receives the events as server-sent events, where proxies strip websocket upgrades
+        const eventSource = () => {
+            const query = [
+                `header.user_id=${encodeURIComponent(this.userId)}`,
+                `header.device_id=${encodeURIComponent(this.deviceId)}`,
+                `header.request_id=${requestId()}`,
+                `resume_from=${resume()}`,
+            ].join('&');
+            const es = new EventSource(`http://${this.target}/sims/streamer/events?${query}`);
+            stop = () => es.close();
+            es.onopen = () => {
+                beat = window.setInterval(ack, interval);
+                alive();
+            };
+            es.onmessage = ev => {
+                alive();
+                if (ev.data !== '{}') {
+                    handle([jsonEvent(JSON.parse(ev.data))]);
+                }
+            };
+            // the gateway ends the stream by an error event of the error, or else the connection failed.
+            // The EventSource would reconnect by itself, but without resuming from the last event
+            es.addEventListener('error', ev => {
+                es.close();
+                const detail = ev instanceof MessageEvent ? ev.data : 'connection failed';
+                end(new Error(`event stream source error: ${detail}`));
+            });
+        };
+
+        // poll receives the events by HTTP long-polling, where proxies buffer streamed responses.
+        // The cursor acks the events so far
+        const poll = () => {
+            const pollUrl = `http://${this.target}/sims/streamer/poll`;
+            const controller = new AbortController();
+            stop = () => controller.abort();
+            let cursor = resume();
+            const next = () => {
+                window
+                    .fetch(pollUrl, {
+                        method: 'POST',
+                        body: JSON.stringify({
+                            header: {...header, request_id: requestId()},
+                            cursor: cursor.toString(),
+                            wait_ms: POLL_WAIT.toString(),
+                        }),
+                        signal: controller.signal,
+                    })
+                    .then(response => {
+                        if (!response.ok) {
+                            throw new Error(`event stream poll: ${response.status}`);
+                        }
+                        return response.json();
+                    })
+                    .then(res => {
+                        handle((res.events || []).map(jsonEvent));
+                        cursor = Number(res.cursor || cursor);
+                        if (!ended && !this.closed) next();
+                    })
+                    .catch(err => end(err));
+            };
+            next();
+        };
 
         window
             .fetch(connectUrl, {method: 'POST', body: body})
@@ -70,47 +272,23 @@ export default class EventStream {
                 }
                 return response.json();
             })
-            .then(() => {
-                ws = new WebSocket(`ws://${this.target}/sims/streamer/events`);
-                ws.onopen = () => {
-                    ws.send(
-                        JSON.stringify({
-                            header: {
-                                user_id: this.userId,
-                                request_id: Math.floor(Date.now() / 1000).toString(),
-                            },
-                        }),
-                    );
-                    beat = window.setInterval(heartbeat, 5000);
-                };
-                ws.onmessage = ev => {
-                    if (ev.data === '{}') return;
-                    const event: Event = JSON.parse(ev.data);
-                    event.data = atob(event.data);
-                    onEvent(event);
-                };
-                ws.onclose = ev => {
-                    window.clearInterval(beat);
-                    onError(
-                        new Error(
-                            `event stream websocket closed: ${ev.code} (clean=${ev.wasClean})`,
-                        ),
-                    );
-                    console.debug(
-                        `event stream websocket closed: ${ev.code} (clean=${ev.wasClean})`,
-                    );
-                };
-                ws.onerror = ev => {
-                    window.clearInterval(beat);
-                    onError(new Error(`event stream websocket error: ${ev}`));
-                    console.debug(`event stream websocket error: ${ev}`);
-                };
+            .then(res => {
+                interval = Number(res.heartbeat_interval_ms || res.heartbeatIntervalMs || interval);
+                timeout = Number(res.inactivity_timeout_ms || res.inactivityTimeoutMs || 2 * interval);
+                switch (this.transport) {
+                    case 'sse':
+                        eventSource();
+                        break;
+                    case 'poll':
+                        poll();
+                        break;
+                    default:
+                        websocket();
+                }
             })
             .catch(err => {
-                if (ws) {
-                    ws.close();
-                }
-                onError(err);
+                stop();
+                end(err);
             });
     }
 
@@ -120,6 +298,8 @@ export default class EventStream {
         const body = JSON.stringify({
             header: {
                 user_id: this.userId,
+                device_id: this.deviceId,
+                user_agent: navigator.userAgent,
             },
         });
         window
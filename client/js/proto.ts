@@ -0,0 +1,222 @@
+// The protobuf wire format of the messages on the event stream, for the sims.v1.proto subprotocol.
+// Each websocket message carries one or more messages, each prefixed by its length in varint.
+
+export const PROTO_SUBPROTOCOL = 'sims.v1.proto';
+
+const EVENT_TYPES = [
+    'EVT_HEARTBEAT',
+    'EVT_TEXT',
+    'EVT_JSON',
+    'EVT_PROTOBUF',
+    'EVT_BINARY',
+    'EVT_RECONNECT',
+    'EVT_KICKED',
+];
+const PRIORITIES = ['PRIORITY_NORMAL', 'PRIORITY_HIGH'];
+
+export interface Header {
+    request_id?: string;
+    user_id?: string;
+    device_id?: string;
+    user_agent?: string;
+}
+
+export interface EventsRequest {
+    header: Header;
+    resume_from: number;
+}
+
+// ProtoEvent has the fields of Event in their JSON names, with the 64 bit integers as strings
+export interface ProtoEvent {
+    type: string;
+    data: Uint8Array;
+    seq?: string;
+    timestamp?: string;
+    topic?: string;
+    ttl_ms?: string;
+    priority?: string;
+    collapse_key?: string;
+    type_url?: string;
+    id?: string;
+    trace_id?: string;
+    span_id?: string;
+}
+
+class Writer {
+    readonly bytes: number[] = [];
+
+    // integers up to 2^53 are exact
+    varint(n: number) {
+        while (n >= 0x80) {
+            this.bytes.push((n % 0x80) | 0x80);
+            n = Math.floor(n / 0x80);
+        }
+        this.bytes.push(n);
+    }
+
+    tag(field: number, wireType: number) {
+        this.varint(field * 8 + wireType);
+    }
+
+    uint(field: number, n: number) {
+        if (n === 0) return;
+        this.tag(field, 0);
+        this.varint(n);
+    }
+
+    // delimited writes p prefixed by its length
+    delimited(p: Uint8Array) {
+        this.varint(p.length);
+        for (let i = 0; i < p.length; i++) this.bytes.push(p[i]);
+    }
+
+    message(field: number, p: Uint8Array) {
+        this.tag(field, 2);
+        this.delimited(p);
+    }
+
+    string(field: number, s?: string) {
+        if (!s) return;
+        this.message(field, new TextEncoder().encode(s));
+    }
+
+    finish(): Uint8Array {
+        return new Uint8Array(this.bytes);
+    }
+}
+
+class Reader {
+    pos = 0;
+    readonly buf: Uint8Array;
+
+    constructor(buf: Uint8Array) {
+        this.buf = buf;
+    }
+
+    done(): boolean {
+        return this.pos >= this.buf.length;
+    }
+
+    varint(): number {
+        let n = 0;
+        let scale = 1;
+        for (;;) {
+            if (this.done()) throw new Error('truncated varint');
+            const b = this.buf[this.pos++];
+            n += (b & 0x7f) * scale;
+            if ((b & 0x80) === 0) return n;
+            scale *= 0x80;
+        }
+    }
+
+    bytes(): Uint8Array {
+        const size = this.varint();
+        if (this.pos + size > this.buf.length) throw new Error('truncated bytes');
+        const p = this.buf.subarray(this.pos, this.pos + size);
+        this.pos += size;
+        return p;
+    }
+
+    string(): string {
+        return new TextDecoder().decode(this.bytes());
+    }
+
+    skip(wireType: number) {
+        switch (wireType) {
+            case 0:
+                this.varint();
+                break;
+            case 1:
+                this.pos += 8;
+                break;
+            case 2:
+                this.bytes();
+                break;
+            case 5:
+                this.pos += 4;
+                break;
+            default:
+                throw new Error(`unsupported wire type ${wireType}`);
+        }
+    }
+}
+
+function encodeHeader(header: Header): Uint8Array {
+    const w = new Writer();
+    w.string(1, header.request_id);
+    w.string(2, header.user_id);
+    w.string(3, header.device_id);
+    w.string(4, header.user_agent);
+    return w.finish();
+}
+
+// encodeEventsRequest returns the websocket message of an EventsRequest
+export function encodeEventsRequest(req: EventsRequest): Uint8Array {
+    const w = new Writer();
+    w.message(1, encodeHeader(req.header));
+    w.uint(2, req.resume_from);
+    const framed = new Writer();
+    framed.delimited(w.finish());
+    return framed.finish();
+}
+
+function decodeEvent(buf: Uint8Array): ProtoEvent {
+    const r = new Reader(buf);
+    const event: ProtoEvent = {type: EVENT_TYPES[0], data: new Uint8Array(0)};
+    while (!r.done()) {
+        const tag = r.varint();
+        const field = Math.floor(tag / 8);
+        const wireType = tag % 8;
+        switch (field) {
+            case 1:
+                event.type = EVENT_TYPES[r.varint()] || 'EVT_UNKNOWN';
+                break;
+            case 2:
+                event.data = r.bytes();
+                break;
+            case 3:
+                event.seq = r.varint().toString();
+                break;
+            case 4:
+                event.timestamp = r.varint().toString();
+                break;
+            case 5:
+                event.topic = r.string();
+                break;
+            case 6:
+                event.ttl_ms = r.varint().toString();
+                break;
+            case 7:
+                event.priority = PRIORITIES[r.varint()];
+                break;
+            case 8:
+                event.collapse_key = r.string();
+                break;
+            case 9:
+                event.type_url = r.string();
+                break;
+            case 10:
+                event.id = r.string();
+                break;
+            case 12:
+                event.trace_id = r.string();
+                break;
+            case 13:
+                event.span_id = r.string();
+                break;
+            default:
+                r.skip(wireType);
+        }
+    }
+    return event;
+}
+
+// decodeEvents returns the events in a websocket message
+export function decodeEvents(buf: Uint8Array): ProtoEvent[] {
+    const r = new Reader(buf);
+    const events: ProtoEvent[] = [];
+    while (!r.done()) {
+        events.push(decodeEvent(r.bytes()));
+    }
+    return events;
+}
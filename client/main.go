@@ -25,7 +25,8 @@ func main() {
 		userID = "homerhuang"
 	}
 	header := &proto.Header{
-		UserId: userID,
+		UserId:   userID,
+		DeviceId: os.Getenv("DEVICE_ID"),
 	}
 	ctx := context.Background()
 	service := micro.NewService()
@@ -6,6 +6,7 @@ require (
 	github.com/gobwas/ws v1.0.3
 	github.com/gogo/protobuf v1.3.1
 	github.com/golang/protobuf v1.4.2
+	github.com/google/uuid v1.1.1
 	github.com/micro/cli/v2 v2.1.2
 	github.com/micro/go-micro/v2 v2.9.1
 	github.com/stretchr/testify v1.6.1
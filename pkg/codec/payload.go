@@ -0,0 +1,236 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	protov2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// Payload is a message carrying the encoded bytes of another message in a field,
+// like google.protobuf.Any but with the type apart, e.g. sims.proto.Event
+type Payload interface {
+	proto.Message
+	// PayloadType returns the type_url of the payload, and whether it is protobuf rather than JSON encoded
+	PayloadType() (typeURL string, protobuf bool)
+}
+
+// RegisterSchemas registers the message types of a FileDescriptorSet file, e.g. by
+// `protoc --include_imports --descriptor_set_out`, so that they can be resolved by type_url.
+// The types already registered are kept. It returns the number of types registered.
+func RegisterSchemas(path string) (int, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	set := new(descriptorpb.FileDescriptorSet)
+	if err := protov2.Unmarshal(data, set); err != nil {
+		return 0, err
+	}
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	var register func(messages protoreflect.MessageDescriptors) error
+	register = func(messages protoreflect.MessageDescriptors) error {
+		for i := 0; i < messages.Len(); i++ {
+			md := messages.Get(i)
+			if md.IsMapEntry() {
+				continue
+			}
+			if _, err := protoregistry.GlobalTypes.FindMessageByName(md.FullName()); err == protoregistry.NotFound {
+				if err := protoregistry.GlobalTypes.RegisterMessage(dynamicpb.NewMessageType(md)); err != nil {
+					return err
+				}
+				n++
+			}
+			if err := register(md.Messages()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		err = register(fd.Messages())
+		return err == nil
+	})
+	return n, err
+}
+
+// Resolve returns the message type of typeURL among the types registered
+func Resolve(typeURL string) (protoreflect.MessageType, error) {
+	mt, err := protoregistry.GlobalTypes.FindMessageByURL(typeURL)
+	if err != nil {
+		return nil, fmt.Errorf("unknown type %q: %w", typeURL, err)
+	}
+	return mt, nil
+}
+
+// Decode parses data as a message of typeURL, which must have no unknown fields
+func Decode(typeURL string, protobuf bool, data []byte) (protoreflect.ProtoMessage, error) {
+	mt, err := Resolve(typeURL)
+	if err != nil {
+		return nil, err
+	}
+	msg := mt.New().Interface()
+	if !protobuf {
+		if err := protojson.Unmarshal(data, msg); err != nil {
+			return nil, fmt.Errorf("invalid %v: %w", typeURL, err)
+		}
+		return msg, nil
+	}
+	if err := protov2.Unmarshal(data, msg); err != nil {
+		return nil, fmt.Errorf("invalid %v: %w", typeURL, err)
+	}
+	if len(msg.ProtoReflect().GetUnknown()) > 0 {
+		return nil, fmt.Errorf("invalid %v: unknown fields", typeURL)
+	}
+	return msg, nil
+}
+
+// placeholder stands for the payload field in the output of MarshalPayload, until it is replaced by the object
+var placeholder = []byte("\x00codec.MarshalPayload\x00")
+
+// MarshalPayload renders msg by m, with its payload field as a JSON object instead of base64,
+// if the type of the payload is known. It implements jsonpb.JSONPBMarshaler for msg.
+func MarshalPayload(m *jsonpb.Marshaler, msg Payload, field protoreflect.Name) ([]byte, error) {
+	src := proto.MessageV2(msg).ProtoReflect()
+	fd := src.Descriptor().Fields().ByName(field)
+	object := renderPayload(m, msg, src.Get(fd).Bytes())
+	if object == nil {
+		return marshalPlain(m, src.Interface())
+	}
+
+	// a dynamic copy is rendered by m as usual, without calling back here
+	dyn := dynamicpb.NewMessage(src.Descriptor())
+	protov2.Merge(dyn, src.Interface())
+	dyn.Set(fd, protoreflect.ValueOfBytes(placeholder))
+	var b bytes.Buffer
+	if err := m.Marshal(&b, proto.MessageV1(dyn)); err != nil {
+		return nil, err
+	}
+	// the value of the field is replaced, so that it is neither duplicated nor moved
+	value := []byte(`"` + base64.StdEncoding.EncodeToString(placeholder) + `"`)
+	return bytes.Replace(b.Bytes(), value, object, 1), nil
+}
+
+// marshalPlain renders msg like m, without calling back MarshalPayload
+func marshalPlain(m *jsonpb.Marshaler, msg protoreflect.ProtoMessage) ([]byte, error) {
+	out, err := protojson.MarshalOptions{
+		UseProtoNames:   m.OrigName,
+		UseEnumNumbers:  m.EnumsAsInts,
+		EmitUnpopulated: m.EmitDefaults,
+	}.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	// protojson varies its spacing on purpose
+	var b bytes.Buffer
+	if m.Indent != "" {
+		err = json.Indent(&b, out, "", m.Indent)
+	} else {
+		err = json.Compact(&b, out)
+	}
+	return b.Bytes(), err
+}
+
+// renderPayload returns the JSON of a payload, or nil if it should be left as bytes.
+// Only objects and arrays are rendered, since a scalar, e.g. of the wrapper types, could not be told from base64.
+func renderPayload(m *jsonpb.Marshaler, msg Payload, data []byte) json.RawMessage {
+	typeURL, protobuf := msg.PayloadType()
+	if typeURL == "" || len(data) == 0 {
+		return nil
+	}
+	if !protobuf {
+		if !json.Valid(data) || !isObject(data) {
+			return nil
+		}
+		return data
+	}
+	payload, err := Decode(typeURL, protobuf, data)
+	if err != nil {
+		return nil
+	}
+	var b bytes.Buffer
+	if err := m.Marshal(&b, proto.MessageV1(payload)); err != nil || !isObject(b.Bytes()) {
+		return nil
+	}
+	return b.Bytes()
+}
+
+// UnmarshalPayload parses b into msg by u, whose payload field is either a JSON object rendered by
+// MarshalPayload, or base64 as usual. It implements jsonpb.JSONPBUnmarshaler for msg.
+func UnmarshalPayload(u *jsonpb.Unmarshaler, b []byte, msg Payload, field protoreflect.Name) error {
+	dst := proto.MessageV2(msg).ProtoReflect()
+	fd := dst.Descriptor().Fields().ByName(field)
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return err
+	}
+	var object json.RawMessage
+	for _, name := range []string{fd.JSONName(), string(fd.Name())} {
+		if v, ok := fields[name]; ok && isObject(v) {
+			object = v
+			delete(fields, name)
+		}
+	}
+	if object != nil {
+		var err error
+		if b, err = json.Marshal(fields); err != nil {
+			return err
+		}
+	}
+
+	// a dynamic message is parsed by u as usual, without calling back here
+	dyn := dynamicpb.NewMessage(dst.Descriptor())
+	if err := u.Unmarshal(bytes.NewReader(b), proto.MessageV1(dyn)); err != nil {
+		return err
+	}
+	protov2.Reset(dst.Interface())
+	protov2.Merge(dst.Interface(), dyn)
+	if object == nil {
+		return nil
+	}
+
+	typeURL, protobuf := msg.PayloadType()
+	if !protobuf {
+		var data bytes.Buffer
+		if err := json.Compact(&data, object); err != nil {
+			return err
+		}
+		dst.Set(fd, protoreflect.ValueOfBytes(data.Bytes()))
+		return nil
+	}
+	mt, err := Resolve(typeURL)
+	if err != nil {
+		return err
+	}
+	payload := mt.New().Interface()
+	if err := u.Unmarshal(bytes.NewReader(object), proto.MessageV1(payload)); err != nil {
+		return err
+	}
+	data, err := protov2.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	dst.Set(fd, protoreflect.ValueOfBytes(data))
+	return nil
+}
+
+// isObject tells if a JSON value is an object or array
+func isObject(v json.RawMessage) bool {
+	v = bytes.TrimLeft(v, " \t\r\n")
+	return len(v) > 0 && (v[0] == '{' || v[0] == '[')
+}
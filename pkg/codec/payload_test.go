@@ -0,0 +1,108 @@
+package codec_test
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/aclisp/sims/proto"
+	"github.com/golang/protobuf/jsonpb"
+	pb "github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/wrappers"
+)
+
+func TestPayload(t *testing.T) {
+	header, _ := pb.Marshal(&proto.Header{UserId: "u1", DeviceId: "d1"})
+	wrapper, _ := pb.Marshal(&wrappers.StringValue{Value: "abc"})
+	for _, c := range []struct {
+		name  string
+		event *proto.Event
+		json  string
+	}{
+		{
+			name:  "protobuf",
+			event: &proto.Event{Type: proto.EventType_EVT_PROTOBUF, Data: header, TypeUrl: "type.googleapis.com/sims.proto.Header"},
+			json:  `"data":{"user_id":"u1","device_id":"d1"}`,
+		},
+		{
+			name:  "json",
+			event: &proto.Event{Type: proto.EventType_EVT_JSON, Data: []byte(`{"user_id":"u1"}`), TypeUrl: "type.googleapis.com/sims.proto.Header"},
+			json:  `"data":{"user_id":"u1"}`,
+		},
+		{
+			name:  "wrapper",
+			event: &proto.Event{Type: proto.EventType_EVT_PROTOBUF, Data: wrapper, TypeUrl: "type.googleapis.com/google.protobuf.StringValue"},
+			json:  `"data":"` + base64.StdEncoding.EncodeToString(wrapper) + `"`,
+		},
+		{
+			name:  "json scalar",
+			event: &proto.Event{Type: proto.EventType_EVT_JSON, Data: []byte(`42`), TypeUrl: "type.googleapis.com/google.protobuf.Int32Value"},
+			json:  `"data":"NDI="`,
+		},
+		{
+			name:  "untyped",
+			event: &proto.Event{Type: proto.EventType_EVT_JSON, Data: []byte(`{}`)},
+			json:  `"data":"e30="`,
+		},
+		{
+			name:  "unknown type",
+			event: &proto.Event{Type: proto.EventType_EVT_PROTOBUF, Data: header, TypeUrl: "type.googleapis.com/unknown.Type"},
+			json:  `"data":"` + base64.StdEncoding.EncodeToString(header) + `"`,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			// nested, as in a request or response
+			req := &proto.UnicastRequest{UserId: "u2", Event: c.event}
+			s := mustMarshal(t, req)
+			if !strings.Contains(s, c.json) {
+				t.Fatalf("marshal %v: %s, want %s in it", c.event, s, c.json)
+			}
+			parsed := new(proto.UnicastRequest)
+			if err := jsonpb.UnmarshalString(s, parsed); err != nil {
+				t.Fatalf("unmarshal %s: %v", s, err)
+			}
+			if !pb.Equal(parsed, req) {
+				t.Fatalf("unmarshal %s: %v, want %v", s, parsed, req)
+			}
+		})
+	}
+}
+
+func mustMarshal(t *testing.T, m pb.Message) string {
+	jm := jsonpb.Marshaler{OrigName: true}
+	s, err := jm.MarshalToString(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestPayloadEmitDefaults(t *testing.T) {
+	// as the JSON codec of the server
+	jm := jsonpb.Marshaler{EmitDefaults: true, OrigName: true}
+	header, _ := pb.Marshal(&proto.Header{UserId: "u1"})
+	for _, event := range []*proto.Event{
+		{Type: proto.EventType_EVT_PROTOBUF, Data: header, TypeUrl: "type.googleapis.com/sims.proto.Header"},
+		{Type: proto.EventType_EVT_JSON, Data: []byte(`{"user_id":"u1"}`), TypeUrl: "type.googleapis.com/sims.proto.Header"},
+		{Type: proto.EventType_EVT_TEXT, Data: []byte("a")},
+		{},
+	} {
+		s, err := jm.MarshalToString(event)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n := strings.Count(s, `"data":`); n != 1 {
+			t.Fatalf("marshal %v: %s, want data once", event, s)
+		}
+		if !strings.Contains(s, `"span_id":""`) {
+			t.Fatalf("marshal %v: %s, want the defaults", event, s)
+		}
+		parsed := new(proto.Event)
+		if err := jsonpb.UnmarshalString(s, parsed); err != nil {
+			t.Fatalf("unmarshal %s: %v", s, err)
+		}
+		if !pb.Equal(parsed, event) {
+			t.Fatalf("unmarshal %s: %v, want %v", s, parsed, event)
+		}
+	}
+}
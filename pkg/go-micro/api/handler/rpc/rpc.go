@@ -197,9 +197,16 @@ func (h *rpcHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	md["Method"] = r.Method
 	// get canonical headers
 	for k, _ := range r.Header {
+		if ctx.IsInternal(k) {
+			continue
+		}
 		// may be need to get all values for key like r.Header.Values() provide in go 1.14
 		md[textproto.CanonicalMIMEHeaderKey(k)] = r.Header.Get(k)
 	}
+	// the services trusting the gateway take the client address from the right, where it is appended
+	if len(ipList) > 0 {
+		md["X-Forwarded-For"] = ipList
+	}
 
 	// merge context with overwrite
 	cx = metadata.MergeContext(cx, md, true)
@@ -214,6 +221,10 @@ func (h *rpcHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		serveWebsocket(cx, w, r, service, c, clientIP)
 		return
 	}
+	if isEventStream(r, service) {
+		serveEventStream(cx, w, r, service, c, clientIP)
+		return
+	}
 
 	// create strategy
 	so := selector.WithStrategy(strategy(clientIP, service.Services))
@@ -9,7 +9,10 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	go_api "github.com/micro/go-micro/v2/api/proto"
+	"github.com/micro/go-micro/v2/errors"
 	"github.com/micro/go-micro/v2/registry"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestRequestPayloadFromRequest(t *testing.T) {
@@ -188,3 +191,20 @@ func TestSelectorStrategy(t *testing.T) {
 	}
 	t.Log("del a node dist", dist, "moved", moved)
 }
+
+func TestEventStream(t *testing.T) {
+	var buf bytes.Buffer
+	writeEvent(&buf, "", []byte(`{"seq":"1"}`))
+	writeEvent(&buf, "error", []byte("a\nb"))
+	if want := "data: {\"seq\":\"1\"}\n\nevent: error\ndata: a\ndata: b\n\n"; buf.String() != want {
+		t.Fatalf("events %q, want %q", buf.String(), want)
+	}
+
+	err := status.Error(codes.InvalidArgument, errors.BadRequest("ERR_NOT_FOUND", "not found").Error())
+	if e := streamError(err); e.Id != "ERR_NOT_FOUND" || e.Code != 400 {
+		t.Fatalf("stream error %v, want the micro error in the status", e)
+	}
+	if e := streamError(status.Error(codes.Unavailable, "gone")); e.Code != 500 || e.Detail != "gone" {
+		t.Fatalf("stream error %v, want an internal error of the status", e)
+	}
+}
@@ -0,0 +1,125 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/micro/go-micro/v2/api"
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/client/selector"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/logger"
+	"google.golang.org/grpc/status"
+)
+
+// isEventStream checks if the client asks for server-sent events of a streaming endpoint
+func isEventStream(r *http.Request, srv *api.Service) bool {
+	if isWebSocket(r) || !strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+		return false
+	}
+	return isStreamEndpoint(srv)
+}
+
+// writeEvent writes a server-sent event of name, or a message if name is empty
+func writeEvent(w io.Writer, name string, data []byte) error {
+	var buf bytes.Buffer
+	if name != "" {
+		fmt.Fprintf(&buf, "event: %s\n", name)
+	}
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		buf.WriteString("data: ")
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	buf.WriteByte('\n')
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// streamError returns the micro error of a failed stream, which is a gRPC status with the grpc client
+func streamError(err error) *errors.Error {
+	s, ok := status.FromError(err)
+	if !ok {
+		return errors.Parse(err.Error())
+	}
+	if e := errors.Parse(s.Message()); e.Code > 0 {
+		return e
+	}
+	return errors.InternalServerError("go.micro.api", "%s", s.Message()).(*errors.Error)
+}
+
+// serveEventStream streams the responses of the rpc to the client as server-sent events in json,
+// e.g. for an EventSource, or where websockets are not available. The request is taken from the query
+// or the body, and an error ends the stream by an `error` event of the error in json
+func serveEventStream(ctx context.Context, w http.ResponseWriter, r *http.Request, service *api.Service, c client.Client, clientIP string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, r, errors.InternalServerError("go.micro.api", "streaming unsupported"))
+		return
+	}
+	payload, err := requestPayload(r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	var request interface{}
+	if len(payload) > 0 && !bytes.Equal(payload, []byte(`{}`)) {
+		m := json.RawMessage(payload)
+		request = &m
+	}
+	req := c.NewRequest(
+		service.Name,
+		service.Endpoint.Name,
+		request,
+		client.WithContentType("application/json"),
+		client.StreamingRequest(),
+	)
+
+	so := selector.WithStrategy(strategy(clientIP, service.Services))
+	stream, err := c.Stream(ctx, req, client.WithSelectOption(so))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	defer stream.Close()
+	if request != nil {
+		if err := stream.Send(request); err != nil {
+			writeError(w, r, err)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	// stop proxies like nginx from buffering the stream
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	rsp := stream.Response()
+	for {
+		buf, err := rsp.Read()
+		if err != nil {
+			if err == io.EOF || strings.Contains(err.Error(), "context canceled") {
+				return
+			}
+			if logger.V(logger.DebugLevel, logger.DefaultLogger) {
+				logger.Debugf("event stream of %v: %v", service.Endpoint.Name, err)
+			}
+			// the status is sent already, so the error goes in the stream
+			writeEvent(w, "error", []byte(streamError(err).Error()))
+			flusher.Flush()
+			return
+		}
+		if err := writeEvent(w, "", buf); err != nil {
+			return
+		}
+		flusher.Flush()
+	}
+}
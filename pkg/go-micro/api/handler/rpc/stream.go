@@ -1,12 +1,16 @@
 package rpc
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
 	"io"
+	"net"
 	"net/http"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gobwas/httphead"
@@ -17,9 +21,129 @@ import (
 	"github.com/micro/go-micro/v2/client/selector"
 	raw "github.com/micro/go-micro/v2/codec/bytes"
 	"github.com/micro/go-micro/v2/logger"
+	"github.com/micro/go-micro/v2/util/websocket"
 )
 
-// serveWebsocket will stream rpc back over websockets assuming json
+const (
+	// wsPingInterval is how often the client of a websocket is pinged
+	wsPingInterval = 15 * time.Second
+	// wsPongWait is the duration after which a silent client is disconnected
+	wsPongWait = 3 * wsPingInterval
+)
+
+// wsConn serializes the frames written to a websocket by the stream, the reader and the pinger,
+// and tracks when the client was last heard of
+type wsConn struct {
+	sync.Mutex
+	conn net.Conn
+	rw   *bufio.ReadWriter
+	seen int64 // unix nanoseconds of the last frame read, accessed atomically
+	// deflate compresses the messages by the negotiated permessage-deflate
+	deflate bool
+	// delimited frames each message of the stream by its length, for subprotocols of websocket.ProtoSuffix
+	delimited bool
+}
+
+func newWsConn(conn net.Conn, rw *bufio.ReadWriter) *wsConn {
+	return &wsConn{
+		conn: conn,
+		rw:   rw,
+		seen: time.Now().UnixNano(),
+	}
+}
+
+// writeMessage sends a message of the stream to the client
+func (c *wsConn) writeMessage(op ws.OpCode, p []byte) error {
+	if c.delimited {
+		p = websocket.AppendDelimited(nil, p)
+	}
+	return c.write(op, p)
+}
+
+// readMessages returns the messages of the stream in the next message from the client
+func (c *wsConn) readMessages() ([][]byte, ws.OpCode, error) {
+	buf, op, err := c.read()
+	if err != nil || !c.delimited {
+		return [][]byte{buf}, op, err
+	}
+	msgs, err := websocket.SplitDelimited(buf)
+	return msgs, op, err
+}
+
+// write sends a message to the client
+func (c *wsConn) write(op ws.OpCode, p []byte) error {
+	c.Lock()
+	defer c.Unlock()
+	if err := websocket.WriteMessage(c.rw, ws.StateServerSide, op, p, c.deflate); err != nil {
+		return err
+	}
+	return c.rw.Flush()
+}
+
+// writeRaw sends encoded frames to the client
+func (c *wsConn) writeRaw(p []byte) error {
+	c.Lock()
+	defer c.Unlock()
+	if _, err := c.rw.Write(p); err != nil {
+		return err
+	}
+	return c.rw.Flush()
+}
+
+// read returns the next text or binary message from the client, answering the control frames in between
+func (c *wsConn) read() ([]byte, ws.OpCode, error) {
+	var reply bytes.Buffer
+	controlHandler := wsutil.ControlFrameHandler(&reply, ws.StateServerSide)
+	handle := func(h ws.Header, r io.Reader) error {
+		atomic.StoreInt64(&c.seen, time.Now().UnixNano())
+		reply.Reset()
+		err := controlHandler(h, r)
+		if reply.Len() > 0 {
+			if werr := c.writeRaw(reply.Bytes()); werr != nil && err == nil {
+				err = werr
+			}
+		}
+		return err
+	}
+	state := ws.StateServerSide
+	if c.deflate {
+		state |= ws.StateExtended
+	}
+	rd := &wsutil.Reader{
+		Source: c.rw,
+		State:  state,
+	}
+	buf, op, err := websocket.ReadMessage(rd, handle)
+	if err == nil {
+		atomic.StoreInt64(&c.seen, time.Now().UnixNano())
+	}
+	return buf, op, err
+}
+
+// pingLoop pings the client until done, and closes the connection if the client has been silent for too long
+func (c *wsConn) pingLoop(done <-chan struct{}) {
+	ticker := time.NewTicker(wsPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if time.Since(time.Unix(0, atomic.LoadInt64(&c.seen))) > wsPongWait {
+				if logger.V(logger.DebugLevel, logger.DefaultLogger) {
+					logger.Debugf("websocket client %v is silent, closing", c.conn.RemoteAddr())
+				}
+				c.conn.Close()
+				return
+			}
+			if err := c.write(ws.OpPing, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// serveWebsocket will stream rpc back over websockets assuming json, or protobuf by the content type or the subprotocol
 func serveWebsocket(ctx context.Context, w http.ResponseWriter, r *http.Request, service *api.Service, c client.Client, clientIP string) {
 	var op ws.OpCode
 
@@ -37,16 +161,29 @@ func serveWebsocket(ctx context.Context, w http.ResponseWriter, r *http.Request,
 		op = ws.OpBinary
 	}
 
-	hdr := make(http.Header)
-	if proto, ok := r.Header["Sec-WebSocket-Protocol"]; ok {
-		for _, p := range proto {
-			switch p {
-			case "binary":
-				hdr["Sec-WebSocket-Protocol"] = []string{"binary"}
+	// the subprotocol selected, if any, or else the first offered is echoed
+	var protocol string
+	for _, v := range r.Header["Sec-Websocket-Protocol"] {
+		for _, p := range strings.Split(v, ",") {
+			switch p = strings.TrimSpace(p); {
+			case protocol != "":
+			case p == "binary":
+				protocol = p
+				op = ws.OpBinary
+			case websocket.IsProto(p):
+				// length-delimited protobuf messages, for clients not able to set the content type, e.g. browsers
+				protocol = p
 				op = ws.OpBinary
+				ct = "application/protobuf"
 			}
 		}
 	}
+	hdr := make(http.Header)
+	deflate := false
+	if ext, ok := websocket.AcceptDeflate(r.Header["Sec-Websocket-Extensions"]); ok {
+		hdr.Set("Sec-Websocket-Extensions", ext)
+		deflate = true
+	}
 	payload, err := requestPayload(r)
 	if err != nil {
 		if logger.V(logger.ErrorLevel, logger.DefaultLogger) {
@@ -57,14 +194,14 @@ func serveWebsocket(ctx context.Context, w http.ResponseWriter, r *http.Request,
 
 	upgrader := ws.HTTPUpgrader{Timeout: 5 * time.Second,
 		Protocol: func(proto string) bool {
-			if strings.Contains(proto, "binary") {
-				return true
+			if protocol != "" {
+				return proto == protocol
 			}
 			// fallback to support all protocols now
 			return true
 		},
 		Extension: func(httphead.Option) bool {
-			// disable extensions for compatibility
+			// permessage-deflate is accepted by the header, with the parameters of its own
 			return false
 		},
 		Header: hdr,
@@ -87,16 +224,25 @@ func serveWebsocket(ctx context.Context, w http.ResponseWriter, r *http.Request,
 		}
 	}()
 
-	payload, op, err = wsutil.ReadClientData(rw)
+	wc := newWsConn(conn, rw)
+	wc.deflate = deflate
+	wc.delimited = websocket.IsProto(protocol)
+	msgs, op, err := wc.readMessages()
 	if err != nil {
 		if logger.V(logger.ErrorLevel, logger.DefaultLogger) {
 			logger.Error(err)
 		}
 		return
 	}
+	if wc.delimited {
+		op = ws.OpBinary
+	}
 
+	// the first message is the request, except that `{}` or no delimited message opens the stream without one
 	var request interface{}
-	if !bytes.Equal(payload, []byte(`{}`)) {
+	empty := len(msgs) == 0 || !wc.delimited && bytes.Equal(msgs[0], []byte(`{}`))
+	if !empty {
+		payload, msgs = msgs[0], msgs[1:]
 		switch ct {
 		case "application/json", "":
 			m := json.RawMessage(payload)
@@ -136,8 +282,21 @@ func serveWebsocket(ctx context.Context, w http.ResponseWriter, r *http.Request,
 			return
 		}
 	}
+	if wc.delimited {
+		for _, msg := range msgs {
+			if err = stream.Send(&raw.Frame{Data: msg}); err != nil {
+				if logger.V(logger.ErrorLevel, logger.DefaultLogger) {
+					logger.Error(err)
+				}
+				return
+			}
+		}
+	}
 
-	go writeLoop(rw, stream)
+	done := make(chan struct{})
+	defer close(done)
+	go wc.pingLoop(done)
+	go writeLoop(wc, stream)
 
 	rsp := stream.Response()
 
@@ -166,13 +325,7 @@ func serveWebsocket(ctx context.Context, w http.ResponseWriter, r *http.Request,
 			}
 
 			// write the response
-			if err := wsutil.WriteServerMessage(rw, op, buf); err != nil {
-				if logger.V(logger.ErrorLevel, logger.DefaultLogger) {
-					logger.Error(err)
-				}
-				return
-			}
-			if err = rw.Flush(); err != nil {
+			if err := wc.writeMessage(op, buf); err != nil {
 				if logger.V(logger.ErrorLevel, logger.DefaultLogger) {
 					logger.Error(err)
 				}
@@ -183,7 +336,7 @@ func serveWebsocket(ctx context.Context, w http.ResponseWriter, r *http.Request,
 }
 
 // writeLoop
-func writeLoop(rw io.ReadWriter, stream client.Stream) {
+func writeLoop(wc *wsConn, stream client.Stream) {
 	// close stream when done
 	defer stream.Close()
 
@@ -192,7 +345,7 @@ func writeLoop(rw io.ReadWriter, stream client.Stream) {
 		case <-stream.Context().Done():
 			return
 		default:
-			buf, op, err := wsutil.ReadClientData(rw)
+			msgs, _, err := wc.readMessages()
 			if err != nil {
 				if wserr, ok := err.(wsutil.ClosedError); ok {
 					switch wserr.Code {
@@ -212,22 +365,17 @@ func writeLoop(rw io.ReadWriter, stream client.Stream) {
 				}
 				return
 			}
-			switch op {
-			default:
-				// not relevant
-				continue
-			case ws.OpText, ws.OpBinary:
-				break
-			}
 			// send to backend
 			// default to trying json
 			// if the extracted payload isn't empty lets use it
-			request := &raw.Frame{Data: buf}
-			if err := stream.Send(request); err != nil {
-				if logger.V(logger.ErrorLevel, logger.DefaultLogger) {
-					logger.Error(err)
+			for _, buf := range msgs {
+				request := &raw.Frame{Data: buf}
+				if err := stream.Send(request); err != nil {
+					if logger.V(logger.ErrorLevel, logger.DefaultLogger) {
+						logger.Error(err)
+					}
+					return
 				}
-				return
 			}
 		}
 	}
@@ -238,7 +386,11 @@ func isStream(r *http.Request, srv *api.Service) bool {
 	if !isWebSocket(r) {
 		return false
 	}
-	// check if the endpoint supports streaming
+	return isStreamEndpoint(srv)
+}
+
+// isStreamEndpoint checks if the endpoint supports streaming
+func isStreamEndpoint(srv *api.Service) bool {
 	for _, service := range srv.Services {
 		for _, ep := range service.Endpoints {
 			// skip if it doesn't match the name
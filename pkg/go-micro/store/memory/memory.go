@@ -121,28 +121,31 @@ func (m *memoryStore) delete(prefix, key string) {
 	m.store.Delete(key)
 }
 
-func (m *memoryStore) list(prefix string, limit, offset uint) []string {
+// list returns the keys starting with keyPrefix. The limit and offset apply to those keys in order.
+func (m *memoryStore) list(prefix, keyPrefix string, limit, offset uint) []string {
 	allItems := m.store.Items()
-	allKeys := make([]string, len(allItems))
-	i := 0
+	allKeys := make([]string, 0, len(allItems))
 
 	for k := range allItems {
 		if !strings.HasPrefix(k, prefix+"/") {
 			continue
 		}
-		allKeys[i] = strings.TrimPrefix(k, prefix+"/")
-		i++
+		k = strings.TrimPrefix(k, prefix+"/")
+		if !strings.HasPrefix(k, keyPrefix) {
+			continue
+		}
+		allKeys = append(allKeys, k)
 	}
 
 	if limit != 0 || offset != 0 {
-		sort.Slice(allKeys, func(i, j int) bool { return allKeys[i] < allKeys[j] })
-		min := func(i, j uint) uint {
-			if i < j {
-				return i
-			}
-			return j
+		sort.Strings(allKeys)
+		if offset >= uint(len(allKeys)) {
+			return nil
+		}
+		allKeys = allKeys[offset:]
+		if limit != 0 && limit < uint(len(allKeys)) {
+			allKeys = allKeys[:limit]
 		}
-		return allKeys[offset:min(limit, uint(len(allKeys)))]
 	}
 
 	return allKeys
@@ -176,12 +179,13 @@ func (m *memoryStore) Read(key string, opts ...store.ReadOption) ([]*store.Recor
 
 	// Handle Prefix / suffix
 	if readOpts.Prefix || readOpts.Suffix {
-		k := m.list(prefix, readOpts.Limit, readOpts.Offset)
+		var keyPrefix string
+		if readOpts.Prefix {
+			keyPrefix = key
+		}
+		k := m.list(prefix, keyPrefix, readOpts.Limit, readOpts.Offset)
 
 		for _, kk := range k {
-			if readOpts.Prefix && !strings.HasPrefix(kk, key) {
-				continue
-			}
 
 			if readOpts.Suffix && !strings.HasSuffix(kk, key) {
 				continue
@@ -267,17 +271,7 @@ func (m *memoryStore) List(opts ...store.ListOption) ([]string, error) {
 	}
 
 	prefix := m.prefix(listOptions.Database, listOptions.Table)
-	keys := m.list(prefix, listOptions.Limit, listOptions.Offset)
-
-	if len(listOptions.Prefix) > 0 {
-		var prefixKeys []string
-		for _, k := range keys {
-			if strings.HasPrefix(k, listOptions.Prefix) {
-				prefixKeys = append(prefixKeys, k)
-			}
-		}
-		keys = prefixKeys
-	}
+	keys := m.list(prefix, listOptions.Prefix, listOptions.Limit, listOptions.Offset)
 
 	if len(listOptions.Suffix) > 0 {
 		var suffixKeys []string
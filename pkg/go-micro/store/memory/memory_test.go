@@ -275,4 +275,25 @@ func basictest(s store.Store, t *testing.T) {
 			t.Error("Expected 5 results, got ", len(results))
 		}
 	}
+	// the limit and offset apply to the keys of the prefix only
+	for i := 0; i < 10; i++ {
+		s.Write(&store.Record{
+			Key:   fmt.Sprintf("b%d", i),
+			Value: []byte{},
+		})
+	}
+	if results, err := s.Read("b", store.ReadLimit(3), store.ReadOffset(2), store.ReadPrefix()); err != nil {
+		t.Error(err)
+	} else {
+		if len(results) != 3 || results[0].Key != "b2" || results[2].Key != "b4" {
+			t.Errorf("Expected b2 to b4, got %v", results)
+		}
+	}
+	if keys, err := s.List(store.ListPrefix("b"), store.ListLimit(2), store.ListOffset(9)); err != nil {
+		t.Error(err)
+	} else {
+		if len(keys) != 1 || keys[0] != "b9" {
+			t.Errorf("Expected b9, got %v", keys)
+		}
+	}
 }
@@ -3,15 +3,28 @@ package ctx
 import (
 	"context"
 	"net/http"
+	"net/textproto"
 	"strings"
 
 	"github.com/micro/go-micro/v2/metadata"
 )
 
+// InternalPrefix is the prefix of the metadata only passed between the backend nodes, e.g. Sims-Forwarded,
+// which the clients may not send through the gateway
+const InternalPrefix = "Sims-"
+
+// IsInternal tells if header k is the metadata only passed between the backend nodes
+func IsInternal(k string) bool {
+	return strings.HasPrefix(textproto.CanonicalMIMEHeaderKey(k), InternalPrefix)
+}
+
 func FromRequest(r *http.Request) context.Context {
 	ctx := context.Background()
 	md := make(metadata.Metadata)
 	for k, v := range r.Header {
+		if IsInternal(k) {
+			continue
+		}
 		md[k] = strings.Join(v, ",")
 	}
 	return metadata.NewContext(ctx, md)
@@ -15,8 +15,9 @@ func TestRequestToContext(t *testing.T) {
 		{
 			&http.Request{
 				Header: http.Header{
-					"Foo1": []string{"bar"},
-					"Foo2": []string{"bar", "baz"},
+					"Foo1":           []string{"bar"},
+					"Foo2":           []string{"bar", "baz"},
+					"Sims-Forwarded": []string{"spoofed"},
 				},
 			},
 			metadata.Metadata{
@@ -37,5 +38,8 @@ func TestRequestToContext(t *testing.T) {
 				t.Fatalf("Expected %s for key %s for expected md %+v, got md %+v", v, k, d.expect, md)
 			}
 		}
+		if len(md) != len(d.expect) {
+			t.Fatalf("Expected md %+v without the internal keys, got md %+v", d.expect, md)
+		}
 	}
 }
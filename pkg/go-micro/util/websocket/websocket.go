@@ -0,0 +1,198 @@
+// Package websocket frames protobuf messages in websocket messages, and compresses
+// the messages by permessage-deflate (RFC 7692) without context takeover
+package websocket
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/gobwas/httphead"
+	"github.com/gobwas/ws"
+	"github.com/gobwas/ws/wsutil"
+)
+
+const (
+	// ProtoSuffix ends the subprotocols of length-delimited protobuf messages, e.g. "chat.v1.proto"
+	ProtoSuffix = ".proto"
+	// Deflate is the name of the permessage-deflate extension
+	Deflate = "permessage-deflate"
+	// DeflateThreshold is the least payload size worth compressing
+	DeflateThreshold = 128
+	// MaxMessageSize is the maximum size of an inflated message
+	MaxMessageSize = 32 << 20
+)
+
+var (
+	// ErrMessageTooLarge is returned when an inflated message exceeds MaxMessageSize
+	ErrMessageTooLarge = errors.New("websocket message too large")
+	// ErrBadDelimited is returned when a message is not a sequence of length-delimited payloads
+	ErrBadDelimited = errors.New("malformed length-delimited message")
+
+	// DeflateOffer is the permessage-deflate offered by clients. Each message is compressed on its own,
+	// so that neither side keeps a compressor per connection
+	DeflateOffer = httphead.NewOption(Deflate, map[string]string{
+		"server_no_context_takeover": "",
+		"client_no_context_takeover": "",
+	})
+
+	// tail ends a compressed payload, the sync flush marker stripped by the sender, and an empty final block
+	tail = []byte{0x00, 0x00, 0xff, 0xff, 0x01, 0x00, 0x00, 0xff, 0xff}
+
+	writers = sync.Pool{New: func() interface{} {
+		w, _ := flate.NewWriter(nil, flate.DefaultCompression)
+		return w
+	}}
+)
+
+// IsProto reports whether a subprotocol carries length-delimited protobuf messages
+func IsProto(protocol string) bool {
+	return strings.HasSuffix(protocol, ProtoSuffix)
+}
+
+// AcceptDeflate selects permessage-deflate from the Sec-WebSocket-Extensions header values offered by a client,
+// and returns the response header value accepting it. Offers limiting the window of the server are declined,
+// because the compressor always uses the largest window
+func AcceptDeflate(offers []string) (string, bool) {
+	for _, offer := range offers {
+		options, ok := httphead.ParseOptions([]byte(offer), nil)
+		if !ok {
+			continue
+		}
+		for _, option := range options {
+			if string(option.Name) != Deflate {
+				continue
+			}
+			if bits, ok := option.Parameters.Get("server_max_window_bits"); ok && string(bits) != "15" {
+				continue
+			}
+			return Deflate + "; server_no_context_takeover; client_no_context_takeover", true
+		}
+	}
+	return "", false
+}
+
+// Deflated reports whether the extensions accepted by a server, in reply to DeflateOffer, compress without context takeover
+func Deflated(extensions []httphead.Option) bool {
+	for _, option := range extensions {
+		if string(option.Name) != Deflate {
+			continue
+		}
+		_, ok := option.Parameters.Get("server_no_context_takeover")
+		return ok
+	}
+	return false
+}
+
+// Compress deflates the payload of a message, to be sent with RSV1 set
+func Compress(p []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := writers.Get().(*flate.Writer)
+	defer writers.Put(w)
+	w.Reset(&buf)
+	if _, err := w.Write(p); err != nil {
+		return nil, err
+	}
+	if err := w.Flush(); err != nil {
+		return nil, err
+	}
+	// strip the sync flush marker, which the receiver appends back
+	return buf.Bytes()[:buf.Len()-4], nil
+}
+
+// Decompress inflates the payload of a message received with RSV1 set
+func Decompress(p []byte) ([]byte, error) {
+	r := flate.NewReader(io.MultiReader(bytes.NewReader(p), bytes.NewReader(tail)))
+	defer r.Close()
+	data, err := ioutil.ReadAll(io.LimitReader(r, MaxMessageSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxMessageSize {
+		return nil, ErrMessageTooLarge
+	}
+	return data, nil
+}
+
+// WriteMessage writes p as a message of op in a single frame, masked if s is the client side.
+// With deflate, the payload is compressed if it is at least DeflateThreshold bytes
+func WriteMessage(w io.Writer, s ws.State, op ws.OpCode, p []byte, deflate bool) error {
+	f := ws.NewFrame(op, true, p)
+	if deflate && len(p) >= DeflateThreshold && !op.IsControl() {
+		c, err := Compress(p)
+		if err != nil {
+			return err
+		}
+		f = ws.NewFrame(op, true, c)
+		f.Header.Rsv = ws.Rsv(true, false, false)
+	}
+	if s.ClientSide() {
+		f = ws.MaskFrame(f)
+	}
+	return ws.WriteFrame(w, f)
+}
+
+// ReadMessage returns the next text or binary message of rd, inflated if compressed, handling the control frames
+// in between by control. The State of rd must be extended if permessage-deflate is negotiated, and its CheckUTF8
+// is left off, because text messages are checked once inflated
+func ReadMessage(rd *wsutil.Reader, control wsutil.FrameHandlerFunc) ([]byte, ws.OpCode, error) {
+	rd.OnIntermediate = control
+	for {
+		hdr, err := rd.NextFrame()
+		if err != nil {
+			return nil, 0, err
+		}
+		if hdr.OpCode.IsControl() {
+			if err := control(hdr, rd); err != nil {
+				return nil, 0, err
+			}
+			continue
+		}
+		if hdr.OpCode&(ws.OpText|ws.OpBinary) == 0 {
+			if err := rd.Discard(); err != nil {
+				return nil, 0, err
+			}
+			continue
+		}
+		data, err := ioutil.ReadAll(rd)
+		if err != nil {
+			return nil, 0, err
+		}
+		if hdr.Rsv1() {
+			if data, err = Decompress(data); err != nil {
+				return nil, 0, err
+			}
+		}
+		if hdr.OpCode == ws.OpText && !utf8.Valid(data) {
+			return nil, 0, wsutil.ErrInvalidUTF8
+		}
+		return data, hdr.OpCode, nil
+	}
+}
+
+// AppendDelimited appends p to dst, prefixed by its length in varint
+func AppendDelimited(dst, p []byte) []byte {
+	var n [binary.MaxVarintLen64]byte
+	dst = append(dst, n[:binary.PutUvarint(n[:], uint64(len(p)))]...)
+	return append(dst, p...)
+}
+
+// SplitDelimited splits a message into its length-delimited payloads
+func SplitDelimited(p []byte) ([][]byte, error) {
+	var payloads [][]byte
+	for len(p) > 0 {
+		size, n := binary.Uvarint(p)
+		if n <= 0 || size > uint64(len(p)-n) {
+			return nil, ErrBadDelimited
+		}
+		payloads = append(payloads, p[n:n+int(size)])
+		p = p[n+int(size):]
+	}
+	return payloads, nil
+}
@@ -0,0 +1,101 @@
+package websocket
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net"
+	"testing"
+
+	"github.com/gobwas/httphead"
+	"github.com/gobwas/ws"
+	"github.com/gobwas/ws/wsutil"
+)
+
+func TestAcceptDeflate(t *testing.T) {
+	testData := []struct {
+		offers []string
+		accept bool
+	}{
+		{nil, false},
+		{[]string{"x-webkit-deflate-frame"}, false},
+		{[]string{"permessage-deflate; client_max_window_bits"}, true},
+		{[]string{"permessage-deflate; server_max_window_bits=10"}, false},
+		{[]string{"permessage-deflate; server_max_window_bits=10, permessage-deflate"}, true},
+		{[]string{"foo", "permessage-deflate; server_no_context_takeover; client_no_context_takeover"}, true},
+	}
+	for _, d := range testData {
+		response, ok := AcceptDeflate(d.offers)
+		if ok != d.accept {
+			t.Fatalf("%v: expected %v, got %v", d.offers, d.accept, ok)
+		}
+		if !ok {
+			continue
+		}
+		options, _ := httphead.ParseOptions([]byte(response), nil)
+		if !Deflated(options) {
+			t.Fatalf("%v: response %q is not deflated", d.offers, response)
+		}
+	}
+}
+
+func TestDelimited(t *testing.T) {
+	payloads := [][]byte{{}, []byte("a"), bytes.Repeat([]byte("b"), 300)}
+	var p []byte
+	for _, payload := range payloads {
+		p = AppendDelimited(p, payload)
+	}
+	split, err := SplitDelimited(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(split) != len(payloads) {
+		t.Fatalf("expected %v payloads, got %v", len(payloads), len(split))
+	}
+	for i := range payloads {
+		if !bytes.Equal(split[i], payloads[i]) {
+			t.Fatalf("payload %v: expected %q, got %q", i, payloads[i], split[i])
+		}
+	}
+	if _, err := SplitDelimited(p[:len(p)-1]); err != ErrBadDelimited {
+		t.Fatalf("expected %v, got %v", ErrBadDelimited, err)
+	}
+}
+
+func TestMessage(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	small := []byte("hello")
+	large := bytes.Repeat([]byte("hello, world "), 100)
+	go func() {
+		for _, p := range [][]byte{small, large, large} {
+			if err := WriteMessage(client, ws.StateClientSide, ws.OpBinary, p, true); err != nil {
+				t.Error(err)
+			}
+		}
+		WriteMessage(client, ws.StateClientSide, ws.OpPing, nil, true)
+		WriteMessage(client, ws.StateClientSide, ws.OpText, large, true)
+	}()
+
+	rd := &wsutil.Reader{Source: server, State: ws.StateServerSide | ws.StateExtended}
+	pings := 0
+	control := func(h ws.Header, r io.Reader) error {
+		pings++
+		_, err := io.Copy(ioutil.Discard, r)
+		return err
+	}
+	for i, expected := range [][]byte{small, large, large, large} {
+		p, op, err := ReadMessage(rd, control)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(p, expected) {
+			t.Fatalf("message %v: expected %v bytes, got %v", i, len(expected), len(p))
+		}
+		if i == 3 && (op != ws.OpText || pings != 1) {
+			t.Fatalf("expected a text message after a ping, got %v after %v pings", op, pings)
+		}
+	}
+}
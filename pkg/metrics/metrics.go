@@ -0,0 +1,246 @@
+// Package metrics keeps counters, gauges and histograms in memory, and serves them in the Prometheus
+// text exposition format, so that they can be scraped without a client library or a push gateway.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// DefBuckets are the default upper bounds of histogram buckets, in seconds
+var DefBuckets = []float64{.0005, .001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Registry is a set of metrics served together
+type Registry struct {
+	lock    sync.Mutex
+	metrics []metric
+}
+
+type metric interface {
+	write(w *bufio.Writer)
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return new(Registry)
+}
+
+func (r *Registry) register(m metric) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.metrics = append(r.metrics, m)
+}
+
+// Write writes every metric in the order of registration
+func (r *Registry) Write(w io.Writer) error {
+	r.lock.Lock()
+	metrics := append([]metric(nil), r.metrics...)
+	r.lock.Unlock()
+	b := bufio.NewWriter(w)
+	for _, m := range metrics {
+		m.write(b)
+	}
+	return b.Flush()
+}
+
+// ServeHTTP implements http.Handler, e.g. at /metrics
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	r.Write(w)
+}
+
+type desc struct {
+	name   string
+	help   string
+	labels []string
+}
+
+func (d *desc) header(w *bufio.Writer, typ string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", d.name, strings.ReplaceAll(d.help, "\n", " "), d.name, typ)
+}
+
+// sample writes a line of name with the label values, and an extra label if any
+func (d *desc) sample(w *bufio.Writer, name string, values []string, extra []string, v float64) {
+	w.WriteString(name)
+	names := d.labels
+	if len(extra) > 0 {
+		names = append(append([]string(nil), names...), extra[0])
+		values = append(append([]string(nil), values...), extra[1])
+	}
+	for i, label := range names {
+		if i == 0 {
+			w.WriteByte('{')
+		} else {
+			w.WriteByte(',')
+		}
+		fmt.Fprintf(w, "%s=\"%s\"", label, escape(values[i]))
+	}
+	if len(names) > 0 {
+		w.WriteByte('}')
+	}
+	w.WriteByte(' ')
+	w.WriteString(formatFloat(v))
+	w.WriteByte('\n')
+}
+
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, +1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// seriesSet finds the series of each combination of label values
+type seriesSet struct {
+	series sync.Map // label values joined by \xff => series
+	create func(values []string) interface{}
+}
+
+func (s *seriesSet) get(values []string) interface{} {
+	key := strings.Join(values, "\xff")
+	if v, ok := s.series.Load(key); ok {
+		return v
+	}
+	v, _ := s.series.LoadOrStore(key, s.create(append([]string(nil), values...)))
+	return v
+}
+
+// sorted returns the series ordered by their label values
+func (s *seriesSet) sorted() []interface{} {
+	var keys []string
+	values := make(map[string]interface{})
+	s.series.Range(func(k, v interface{}) bool {
+		keys = append(keys, k.(string))
+		values[k.(string)] = v
+		return true
+	})
+	sort.Strings(keys)
+	result := make([]interface{}, len(keys))
+	for i, key := range keys {
+		result[i] = values[key]
+	}
+	return result
+}
+
+// Counter is a monotonically increasing count, partitioned by its labels
+type Counter struct {
+	desc
+	set seriesSet
+}
+
+type counterSeries struct {
+	values []string
+	n      uint64
+}
+
+// NewCounter registers a counter with the names of its labels
+func (r *Registry) NewCounter(name, help string, labels ...string) *Counter {
+	c := &Counter{desc: desc{name: name, help: help, labels: labels}}
+	c.set.create = func(values []string) interface{} { return &counterSeries{values: values} }
+	r.register(c)
+	return c
+}
+
+// Add adds n to the series of the label values, which are in the order of the label names
+func (c *Counter) Add(n uint64, values ...string) {
+	atomic.AddUint64(&c.set.get(values).(*counterSeries).n, n)
+}
+
+// Inc adds one to the series of the label values
+func (c *Counter) Inc(values ...string) {
+	c.Add(1, values...)
+}
+
+func (c *Counter) write(w *bufio.Writer) {
+	c.header(w, "counter")
+	for _, s := range c.set.sorted() {
+		s := s.(*counterSeries)
+		c.sample(w, c.name, s.values, nil, float64(atomic.LoadUint64(&s.n)))
+	}
+}
+
+// GaugeFunc is a value taken when the metrics are written
+type GaugeFunc struct {
+	desc
+	f func() float64
+}
+
+// NewGaugeFunc registers a gauge of the value returned by f
+func (r *Registry) NewGaugeFunc(name, help string, f func() float64) *GaugeFunc {
+	g := &GaugeFunc{desc: desc{name: name, help: help}, f: f}
+	r.register(g)
+	return g
+}
+
+func (g *GaugeFunc) write(w *bufio.Writer) {
+	g.header(w, "gauge")
+	g.sample(w, g.name, nil, nil, g.f())
+}
+
+// Histogram counts observations in buckets, partitioned by its labels
+type Histogram struct {
+	desc
+	buckets []float64
+	set     seriesSet
+}
+
+type histogramSeries struct {
+	values []string
+	counts []uint64 // of each bucket, and the last of +Inf
+	sum    uint64   // float64 bits
+}
+
+// NewHistogram registers a histogram of the upper bounds of buckets in increasing order, and the names of its labels
+func (r *Registry) NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	h := &Histogram{desc: desc{name: name, help: help, labels: labels}, buckets: buckets}
+	h.set.create = func(values []string) interface{} {
+		return &histogramSeries{values: values, counts: make([]uint64, len(buckets)+1)}
+	}
+	r.register(h)
+	return h
+}
+
+// Observe adds v to the series of the label values
+func (h *Histogram) Observe(v float64, values ...string) {
+	s := h.set.get(values).(*histogramSeries)
+	atomic.AddUint64(&s.counts[sort.SearchFloat64s(h.buckets, v)], 1)
+	for {
+		old := atomic.LoadUint64(&s.sum)
+		if atomic.CompareAndSwapUint64(&s.sum, old, math.Float64bits(math.Float64frombits(old)+v)) {
+			return
+		}
+	}
+}
+
+func (h *Histogram) write(w *bufio.Writer) {
+	h.header(w, "histogram")
+	for _, s := range h.set.sorted() {
+		s := s.(*histogramSeries)
+		var count uint64
+		for i := range s.counts {
+			count += atomic.LoadUint64(&s.counts[i])
+			le := math.Inf(+1)
+			if i < len(h.buckets) {
+				le = h.buckets[i]
+			}
+			h.sample(w, h.name+"_bucket", s.values, []string{"le", formatFloat(le)}, float64(count))
+		}
+		h.sample(w, h.name+"_sum", s.values, nil, math.Float64frombits(atomic.LoadUint64(&s.sum)))
+		h.sample(w, h.name+"_count", s.values, nil, float64(count))
+	}
+}
@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	r := NewRegistry()
+	c := r.NewCounter("calls_total", "Calls by code.", "code")
+	c.Inc("OK")
+	c.Add(2, `ERR "x"`)
+	r.NewGaugeFunc("size", "Size.", func() float64 { return 3 })
+	h := r.NewHistogram("latency_seconds", "Latency.", []float64{0.1, 1})
+	h.Observe(0.05)
+	h.Observe(0.1)
+	h.Observe(5)
+
+	var b strings.Builder
+	if err := r.Write(&b); err != nil {
+		t.Fatal(err)
+	}
+	want := `# HELP calls_total Calls by code.
+# TYPE calls_total counter
+calls_total{code="ERR \"x\""} 2
+calls_total{code="OK"} 1
+# HELP size Size.
+# TYPE size gauge
+size 3
+# HELP latency_seconds Latency.
+# TYPE latency_seconds histogram
+latency_seconds_bucket{le="0.1"} 2
+latency_seconds_bucket{le="1"} 2
+latency_seconds_bucket{le="+Inf"} 3
+latency_seconds_sum 5.15
+latency_seconds_count 3
+`
+	if b.String() != want {
+		t.Fatalf("wrote\n%s\nwant\n%s", b.String(), want)
+	}
+}
@@ -0,0 +1,21 @@
+package proto
+
+import (
+	"github.com/aclisp/sims/pkg/codec"
+	"github.com/golang/protobuf/jsonpb"
+)
+
+// PayloadType returns the type of data, see codec.Payload
+func (m *Event) PayloadType() (string, bool) {
+	return m.TypeUrl, m.Type == EventType_EVT_PROTOBUF
+}
+
+// MarshalJSONPB renders data as a JSON object if its type_url is known, or else base64
+func (m *Event) MarshalJSONPB(jm *jsonpb.Marshaler) ([]byte, error) {
+	return codec.MarshalPayload(jm, m, "data")
+}
+
+// UnmarshalJSONPB parses data from either a JSON object of its type_url, or base64
+func (m *Event) UnmarshalJSONPB(ju *jsonpb.Unmarshaler, b []byte) error {
+	return codec.UnmarshalPayload(ju, b, m, "data")
+}
@@ -23,36 +23,66 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 type ErrorCode int32
 
 const (
-	ErrorCode_ERR_UNSPECIFIED        ErrorCode = 0
-	ErrorCode_ERR_NOT_FOUND          ErrorCode = 1
-	ErrorCode_ERR_ALREADY_EXISTS     ErrorCode = 2
-	ErrorCode_ERR_MISSING_USERID     ErrorCode = 3
-	ErrorCode_ERR_MISSING_HEADER     ErrorCode = 4
-	ErrorCode_ERR_NO_CONSUMER        ErrorCode = 5
-	ErrorCode_ERR_MISSING_EVENT      ErrorCode = 6
-	ErrorCode_ERR_INVALID_EVENT_TYPE ErrorCode = 7
+	ErrorCode_ERR_UNSPECIFIED          ErrorCode = 0
+	ErrorCode_ERR_NOT_FOUND            ErrorCode = 1
+	ErrorCode_ERR_ALREADY_EXISTS       ErrorCode = 2
+	ErrorCode_ERR_MISSING_USERID       ErrorCode = 3
+	ErrorCode_ERR_MISSING_HEADER       ErrorCode = 4
+	ErrorCode_ERR_NO_CONSUMER          ErrorCode = 5
+	ErrorCode_ERR_MISSING_EVENT        ErrorCode = 6
+	ErrorCode_ERR_INVALID_EVENT_TYPE   ErrorCode = 7
+	ErrorCode_ERR_MISSING_GROUPID      ErrorCode = 8
+	ErrorCode_ERR_MISSING_TOPIC        ErrorCode = 9
+	ErrorCode_ERR_UNAUTHENTICATED      ErrorCode = 10
+	ErrorCode_ERR_PERMISSION_DENIED    ErrorCode = 11
+	ErrorCode_ERR_DRAINING             ErrorCode = 12
+	ErrorCode_ERR_INVALID_FRAME        ErrorCode = 13
+	ErrorCode_ERR_RATE_LIMITED         ErrorCode = 14
+	ErrorCode_ERR_INVALID_PAYLOAD      ErrorCode = 15
+	ErrorCode_ERR_INVALID_CONVERSATION ErrorCode = 16
+	ErrorCode_ERR_BANNED               ErrorCode = 17
 )
 
 var ErrorCode_name = map[int32]string{
-	0: "ERR_UNSPECIFIED",
-	1: "ERR_NOT_FOUND",
-	2: "ERR_ALREADY_EXISTS",
-	3: "ERR_MISSING_USERID",
-	4: "ERR_MISSING_HEADER",
-	5: "ERR_NO_CONSUMER",
-	6: "ERR_MISSING_EVENT",
-	7: "ERR_INVALID_EVENT_TYPE",
+	0:  "ERR_UNSPECIFIED",
+	1:  "ERR_NOT_FOUND",
+	2:  "ERR_ALREADY_EXISTS",
+	3:  "ERR_MISSING_USERID",
+	4:  "ERR_MISSING_HEADER",
+	5:  "ERR_NO_CONSUMER",
+	6:  "ERR_MISSING_EVENT",
+	7:  "ERR_INVALID_EVENT_TYPE",
+	8:  "ERR_MISSING_GROUPID",
+	9:  "ERR_MISSING_TOPIC",
+	10: "ERR_UNAUTHENTICATED",
+	11: "ERR_PERMISSION_DENIED",
+	12: "ERR_DRAINING",
+	13: "ERR_INVALID_FRAME",
+	14: "ERR_RATE_LIMITED",
+	15: "ERR_INVALID_PAYLOAD",
+	16: "ERR_INVALID_CONVERSATION",
+	17: "ERR_BANNED",
 }
 
 var ErrorCode_value = map[string]int32{
-	"ERR_UNSPECIFIED":        0,
-	"ERR_NOT_FOUND":          1,
-	"ERR_ALREADY_EXISTS":     2,
-	"ERR_MISSING_USERID":     3,
-	"ERR_MISSING_HEADER":     4,
-	"ERR_NO_CONSUMER":        5,
-	"ERR_MISSING_EVENT":      6,
-	"ERR_INVALID_EVENT_TYPE": 7,
+	"ERR_UNSPECIFIED":          0,
+	"ERR_NOT_FOUND":            1,
+	"ERR_ALREADY_EXISTS":       2,
+	"ERR_MISSING_USERID":       3,
+	"ERR_MISSING_HEADER":       4,
+	"ERR_NO_CONSUMER":          5,
+	"ERR_MISSING_EVENT":        6,
+	"ERR_INVALID_EVENT_TYPE":   7,
+	"ERR_MISSING_GROUPID":      8,
+	"ERR_MISSING_TOPIC":        9,
+	"ERR_UNAUTHENTICATED":      10,
+	"ERR_PERMISSION_DENIED":    11,
+	"ERR_DRAINING":             12,
+	"ERR_INVALID_FRAME":        13,
+	"ERR_RATE_LIMITED":         14,
+	"ERR_INVALID_PAYLOAD":      15,
+	"ERR_INVALID_CONVERSATION": 16,
+	"ERR_BANNED":               17,
 }
 
 func (x ErrorCode) String() string {
@@ -63,6 +93,31 @@ func (ErrorCode) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_baee4f6301954b8c, []int{0}
 }
 
+type PresenceStatus int32
+
+const (
+	PresenceStatus_PRESENCE_OFFLINE PresenceStatus = 0
+	PresenceStatus_PRESENCE_ONLINE  PresenceStatus = 1
+)
+
+var PresenceStatus_name = map[int32]string{
+	0: "PRESENCE_OFFLINE",
+	1: "PRESENCE_ONLINE",
+}
+
+var PresenceStatus_value = map[string]int32{
+	"PRESENCE_OFFLINE": 0,
+	"PRESENCE_ONLINE":  1,
+}
+
+func (x PresenceStatus) String() string {
+	return proto.EnumName(PresenceStatus_name, int32(x))
+}
+
+func (PresenceStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{1}
+}
+
 type EventType int32
 
 const (
@@ -71,6 +126,8 @@ const (
 	EventType_EVT_JSON      EventType = 2
 	EventType_EVT_PROTOBUF  EventType = 3
 	EventType_EVT_BINARY    EventType = 4
+	EventType_EVT_RECONNECT EventType = 5
+	EventType_EVT_KICKED    EventType = 6
 )
 
 var EventType_name = map[int32]string{
@@ -79,6 +136,8 @@ var EventType_name = map[int32]string{
 	2: "EVT_JSON",
 	3: "EVT_PROTOBUF",
 	4: "EVT_BINARY",
+	5: "EVT_RECONNECT",
+	6: "EVT_KICKED",
 }
 
 var EventType_value = map[string]int32{
@@ -87,6 +146,8 @@ var EventType_value = map[string]int32{
 	"EVT_JSON":      2,
 	"EVT_PROTOBUF":  3,
 	"EVT_BINARY":    4,
+	"EVT_RECONNECT": 5,
+	"EVT_KICKED":    6,
 }
 
 func (x EventType) String() string {
@@ -94,7 +155,88 @@ func (x EventType) String() string {
 }
 
 func (EventType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_baee4f6301954b8c, []int{1}
+	return fileDescriptor_baee4f6301954b8c, []int{2}
+}
+
+type Priority int32
+
+const (
+	Priority_PRIORITY_NORMAL Priority = 0
+	Priority_PRIORITY_HIGH   Priority = 1
+)
+
+var Priority_name = map[int32]string{
+	0: "PRIORITY_NORMAL",
+	1: "PRIORITY_HIGH",
+}
+
+var Priority_value = map[string]int32{
+	"PRIORITY_NORMAL": 0,
+	"PRIORITY_HIGH":   1,
+}
+
+func (x Priority) String() string {
+	return proto.EnumName(Priority_name, int32(x))
+}
+
+func (Priority) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{3}
+}
+
+type HookEvent int32
+
+const (
+	HookEvent_HOOK_CONNECTED     HookEvent = 0
+	HookEvent_HOOK_DISCONNECTED  HookEvent = 1
+	HookEvent_HOOK_TIMED_OUT     HookEvent = 2
+	HookEvent_HOOK_UNDELIVERABLE HookEvent = 3
+)
+
+var HookEvent_name = map[int32]string{
+	0: "HOOK_CONNECTED",
+	1: "HOOK_DISCONNECTED",
+	2: "HOOK_TIMED_OUT",
+	3: "HOOK_UNDELIVERABLE",
+}
+
+var HookEvent_value = map[string]int32{
+	"HOOK_CONNECTED":     0,
+	"HOOK_DISCONNECTED":  1,
+	"HOOK_TIMED_OUT":     2,
+	"HOOK_UNDELIVERABLE": 3,
+}
+
+func (x HookEvent) String() string {
+	return proto.EnumName(HookEvent_name, int32(x))
+}
+
+func (HookEvent) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{4}
+}
+
+type ReceiptStatus int32
+
+const (
+	ReceiptStatus_RECEIPT_DELIVERED ReceiptStatus = 0
+	ReceiptStatus_RECEIPT_READ      ReceiptStatus = 1
+)
+
+var ReceiptStatus_name = map[int32]string{
+	0: "RECEIPT_DELIVERED",
+	1: "RECEIPT_READ",
+}
+
+var ReceiptStatus_value = map[string]int32{
+	"RECEIPT_DELIVERED": 0,
+	"RECEIPT_READ":      1,
+}
+
+func (x ReceiptStatus) String() string {
+	return proto.EnumName(ReceiptStatus_name, int32(x))
+}
+
+func (ReceiptStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{5}
 }
 
 type ServerConfig struct {
@@ -192,11 +334,24 @@ func (m *Header) GetUserAgent() string {
 }
 
 type Event struct {
-	Type                 EventType `protobuf:"varint,1,opt,name=type,proto3,enum=sims.proto.EventType" json:"type,omitempty"`
-	Data                 []byte    `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	Type        EventType `protobuf:"varint,1,opt,name=type,proto3,enum=sims.proto.EventType" json:"type,omitempty"`
+	Data        []byte    `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Seq         uint64    `protobuf:"varint,3,opt,name=seq,proto3" json:"seq,omitempty"`
+	Timestamp   int64     `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Topic       string    `protobuf:"bytes,5,opt,name=topic,proto3" json:"topic,omitempty"`
+	TtlMs       int64     `protobuf:"varint,6,opt,name=ttl_ms,json=ttlMs,proto3" json:"ttl_ms,omitempty"`
+	Priority    Priority  `protobuf:"varint,7,opt,name=priority,proto3,enum=sims.proto.Priority" json:"priority,omitempty"`
+	CollapseKey string    `protobuf:"bytes,8,opt,name=collapse_key,json=collapseKey,proto3" json:"collapse_key,omitempty"`
+	// The message type of data for EVT_JSON and EVT_PROTOBUF, like google.protobuf.Any, e.g.
+	// type.googleapis.com/chat.Message. The data is validated against it, and rendered as a JSON object
+	TypeUrl              string   `protobuf:"bytes,9,opt,name=type_url,json=typeUrl,proto3" json:"type_url,omitempty"`
+	Id                   string   `protobuf:"bytes,10,opt,name=id,proto3" json:"id,omitempty"`
+	ReceiptTo            string   `protobuf:"bytes,11,opt,name=receipt_to,json=receiptTo,proto3" json:"receipt_to,omitempty"`
+	TraceId              string   `protobuf:"bytes,12,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	SpanId               string   `protobuf:"bytes,13,opt,name=span_id,json=spanId,proto3" json:"span_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Event) Reset()         { *m = Event{} }
@@ -238,6 +393,179 @@ func (m *Event) GetData() []byte {
 	return nil
 }
 
+func (m *Event) GetSeq() uint64 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+func (m *Event) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *Event) GetTopic() string {
+	if m != nil {
+		return m.Topic
+	}
+	return ""
+}
+
+func (m *Event) GetTtlMs() int64 {
+	if m != nil {
+		return m.TtlMs
+	}
+	return 0
+}
+
+func (m *Event) GetPriority() Priority {
+	if m != nil {
+		return m.Priority
+	}
+	return Priority_PRIORITY_NORMAL
+}
+
+func (m *Event) GetCollapseKey() string {
+	if m != nil {
+		return m.CollapseKey
+	}
+	return ""
+}
+
+func (m *Event) GetTypeUrl() string {
+	if m != nil {
+		return m.TypeUrl
+	}
+	return ""
+}
+
+func (m *Event) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Event) GetReceiptTo() string {
+	if m != nil {
+		return m.ReceiptTo
+	}
+	return ""
+}
+
+func (m *Event) GetTraceId() string {
+	if m != nil {
+		return m.TraceId
+	}
+	return ""
+}
+
+func (m *Event) GetSpanId() string {
+	if m != nil {
+		return m.SpanId
+	}
+	return ""
+}
+
+// Reconnect suggests how a client reconnects when its node is shutting down
+type Reconnect struct {
+	Target               string   `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	BackoffMs            int64    `protobuf:"varint,2,opt,name=backoff_ms,json=backoffMs,proto3" json:"backoff_ms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Reconnect) Reset()         { *m = Reconnect{} }
+func (m *Reconnect) String() string { return proto.CompactTextString(m) }
+func (*Reconnect) ProtoMessage()    {}
+func (*Reconnect) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{3}
+}
+
+func (m *Reconnect) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Reconnect.Unmarshal(m, b)
+}
+func (m *Reconnect) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Reconnect.Marshal(b, m, deterministic)
+}
+func (m *Reconnect) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Reconnect.Merge(m, src)
+}
+func (m *Reconnect) XXX_Size() int {
+	return xxx_messageInfo_Reconnect.Size(m)
+}
+func (m *Reconnect) XXX_DiscardUnknown() {
+	xxx_messageInfo_Reconnect.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Reconnect proto.InternalMessageInfo
+
+func (m *Reconnect) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+func (m *Reconnect) GetBackoffMs() int64 {
+	if m != nil {
+		return m.BackoffMs
+	}
+	return 0
+}
+
+// Kicked tells a client why its channel is closed
+type Kicked struct {
+	Reason               string   `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	BannedUntil          int64    `protobuf:"varint,2,opt,name=banned_until,json=bannedUntil,proto3" json:"banned_until,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Kicked) Reset()         { *m = Kicked{} }
+func (m *Kicked) String() string { return proto.CompactTextString(m) }
+func (*Kicked) ProtoMessage()    {}
+func (*Kicked) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{4}
+}
+
+func (m *Kicked) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Kicked.Unmarshal(m, b)
+}
+func (m *Kicked) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Kicked.Marshal(b, m, deterministic)
+}
+func (m *Kicked) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Kicked.Merge(m, src)
+}
+func (m *Kicked) XXX_Size() int {
+	return xxx_messageInfo_Kicked.Size(m)
+}
+func (m *Kicked) XXX_DiscardUnknown() {
+	xxx_messageInfo_Kicked.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Kicked proto.InternalMessageInfo
+
+func (m *Kicked) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *Kicked) GetBannedUntil() int64 {
+	if m != nil {
+		return m.BannedUntil
+	}
+	return 0
+}
+
 type Selector struct {
 	UserAgent            string   `protobuf:"bytes,1,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -249,7 +577,7 @@ func (m *Selector) Reset()         { *m = Selector{} }
 func (m *Selector) String() string { return proto.CompactTextString(m) }
 func (*Selector) ProtoMessage()    {}
 func (*Selector) Descriptor() ([]byte, []int) {
-	return fileDescriptor_baee4f6301954b8c, []int{3}
+	return fileDescriptor_baee4f6301954b8c, []int{5}
 }
 
 func (m *Selector) XXX_Unmarshal(b []byte) error {
@@ -278,7 +606,10 @@ func (m *Selector) GetUserAgent() string {
 }
 
 type EventsRequest struct {
-	Header               *Header  `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Header *Header `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	// Redeliver the unacknowledged events from this sequence, i.e. the last received sequence + 1.
+	// Zero starts afresh, dropping the unacknowledged events.
+	ResumeFrom           uint64   `protobuf:"varint,2,opt,name=resume_from,json=resumeFrom,proto3" json:"resume_from,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -288,7 +619,7 @@ func (m *EventsRequest) Reset()         { *m = EventsRequest{} }
 func (m *EventsRequest) String() string { return proto.CompactTextString(m) }
 func (*EventsRequest) ProtoMessage()    {}
 func (*EventsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_baee4f6301954b8c, []int{4}
+	return fileDescriptor_baee4f6301954b8c, []int{6}
 }
 
 func (m *EventsRequest) XXX_Unmarshal(b []byte) error {
@@ -316,234 +647,2718 @@ func (m *EventsRequest) GetHeader() *Header {
 	return nil
 }
 
-type ConnectRequest struct {
-	Header               *Header  `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+func (m *EventsRequest) GetResumeFrom() uint64 {
+	if m != nil {
+		return m.ResumeFrom
+	}
+	return 0
+}
+
+type PollRequest struct {
+	Header *Header `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	// The cursor returned by the last poll, which acknowledges the events before it, like resume_from
+	// of Events. Zero starts afresh, dropping the unacknowledged events.
+	Cursor               uint64   `protobuf:"varint,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	WaitMs               int64    `protobuf:"varint,3,opt,name=wait_ms,json=waitMs,proto3" json:"wait_ms,omitempty"`
+	MaxEvents            int32    `protobuf:"varint,4,opt,name=max_events,json=maxEvents,proto3" json:"max_events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PollRequest) Reset()         { *m = PollRequest{} }
+func (m *PollRequest) String() string { return proto.CompactTextString(m) }
+func (*PollRequest) ProtoMessage()    {}
+func (*PollRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{7}
+}
+
+func (m *PollRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PollRequest.Unmarshal(m, b)
+}
+func (m *PollRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PollRequest.Marshal(b, m, deterministic)
+}
+func (m *PollRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PollRequest.Merge(m, src)
+}
+func (m *PollRequest) XXX_Size() int {
+	return xxx_messageInfo_PollRequest.Size(m)
+}
+func (m *PollRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PollRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PollRequest proto.InternalMessageInfo
+
+func (m *PollRequest) GetHeader() *Header {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *PollRequest) GetCursor() uint64 {
+	if m != nil {
+		return m.Cursor
+	}
+	return 0
+}
+
+func (m *PollRequest) GetWaitMs() int64 {
+	if m != nil {
+		return m.WaitMs
+	}
+	return 0
+}
+
+func (m *PollRequest) GetMaxEvents() int32 {
+	if m != nil {
+		return m.MaxEvents
+	}
+	return 0
+}
+
+type PollResponse struct {
+	Events               []*Event `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	Cursor               uint64   `protobuf:"varint,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PollResponse) Reset()         { *m = PollResponse{} }
+func (m *PollResponse) String() string { return proto.CompactTextString(m) }
+func (*PollResponse) ProtoMessage()    {}
+func (*PollResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{8}
+}
+
+func (m *PollResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PollResponse.Unmarshal(m, b)
+}
+func (m *PollResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PollResponse.Marshal(b, m, deterministic)
+}
+func (m *PollResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PollResponse.Merge(m, src)
+}
+func (m *PollResponse) XXX_Size() int {
+	return xxx_messageInfo_PollResponse.Size(m)
+}
+func (m *PollResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PollResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PollResponse proto.InternalMessageInfo
+
+func (m *PollResponse) GetEvents() []*Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *PollResponse) GetCursor() uint64 {
+	if m != nil {
+		return m.Cursor
+	}
+	return 0
+}
+
+type ConnectRequest struct {
+	Header *Header `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	// How often the server pings by EVT_HEARTBEAT on Streamer.Events, which the client expects. Zero for the default
+	HeartbeatIntervalMs  int64    `protobuf:"varint,2,opt,name=heartbeat_interval_ms,json=heartbeatIntervalMs,proto3" json:"heartbeat_interval_ms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConnectRequest) Reset()         { *m = ConnectRequest{} }
+func (m *ConnectRequest) String() string { return proto.CompactTextString(m) }
+func (*ConnectRequest) ProtoMessage()    {}
+func (*ConnectRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{9}
+}
+
+func (m *ConnectRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConnectRequest.Unmarshal(m, b)
+}
+func (m *ConnectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConnectRequest.Marshal(b, m, deterministic)
+}
+func (m *ConnectRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectRequest.Merge(m, src)
+}
+func (m *ConnectRequest) XXX_Size() int {
+	return xxx_messageInfo_ConnectRequest.Size(m)
+}
+func (m *ConnectRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConnectRequest proto.InternalMessageInfo
+
+func (m *ConnectRequest) GetHeader() *Header {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *ConnectRequest) GetHeartbeatIntervalMs() int64 {
+	if m != nil {
+		return m.HeartbeatIntervalMs
+	}
+	return 0
+}
+
+type ConnectResponse struct {
+	HeartbeatIntervalMs  int64    `protobuf:"varint,1,opt,name=heartbeat_interval_ms,json=heartbeatIntervalMs,proto3" json:"heartbeat_interval_ms,omitempty"`
+	InactivityTimeoutMs  int64    `protobuf:"varint,2,opt,name=inactivity_timeout_ms,json=inactivityTimeoutMs,proto3" json:"inactivity_timeout_ms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConnectResponse) Reset()         { *m = ConnectResponse{} }
+func (m *ConnectResponse) String() string { return proto.CompactTextString(m) }
+func (*ConnectResponse) ProtoMessage()    {}
+func (*ConnectResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{10}
+}
+
+func (m *ConnectResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConnectResponse.Unmarshal(m, b)
+}
+func (m *ConnectResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConnectResponse.Marshal(b, m, deterministic)
+}
+func (m *ConnectResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectResponse.Merge(m, src)
+}
+func (m *ConnectResponse) XXX_Size() int {
+	return xxx_messageInfo_ConnectResponse.Size(m)
+}
+func (m *ConnectResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConnectResponse proto.InternalMessageInfo
+
+func (m *ConnectResponse) GetHeartbeatIntervalMs() int64 {
+	if m != nil {
+		return m.HeartbeatIntervalMs
+	}
+	return 0
+}
+
+func (m *ConnectResponse) GetInactivityTimeoutMs() int64 {
+	if m != nil {
+		return m.InactivityTimeoutMs
+	}
+	return 0
+}
+
+type SessionConnect struct {
+	Header               *Header  `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	HeartbeatIntervalMs  int64    `protobuf:"varint,2,opt,name=heartbeat_interval_ms,json=heartbeatIntervalMs,proto3" json:"heartbeat_interval_ms,omitempty"`
+	ResumeFrom           uint64   `protobuf:"varint,3,opt,name=resume_from,json=resumeFrom,proto3" json:"resume_from,omitempty"`
+	Topic                []string `protobuf:"bytes,4,rep,name=topic,proto3" json:"topic,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SessionConnect) Reset()         { *m = SessionConnect{} }
+func (m *SessionConnect) String() string { return proto.CompactTextString(m) }
+func (*SessionConnect) ProtoMessage()    {}
+func (*SessionConnect) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{11}
+}
+
+func (m *SessionConnect) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SessionConnect.Unmarshal(m, b)
+}
+func (m *SessionConnect) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SessionConnect.Marshal(b, m, deterministic)
+}
+func (m *SessionConnect) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SessionConnect.Merge(m, src)
+}
+func (m *SessionConnect) XXX_Size() int {
+	return xxx_messageInfo_SessionConnect.Size(m)
+}
+func (m *SessionConnect) XXX_DiscardUnknown() {
+	xxx_messageInfo_SessionConnect.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SessionConnect proto.InternalMessageInfo
+
+func (m *SessionConnect) GetHeader() *Header {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *SessionConnect) GetHeartbeatIntervalMs() int64 {
+	if m != nil {
+		return m.HeartbeatIntervalMs
+	}
+	return 0
+}
+
+func (m *SessionConnect) GetResumeFrom() uint64 {
+	if m != nil {
+		return m.ResumeFrom
+	}
+	return 0
+}
+
+func (m *SessionConnect) GetTopic() []string {
+	if m != nil {
+		return m.Topic
+	}
+	return nil
+}
+
+// SessionRequest is a frame from the client. The header of a frame is ignored, it is the one of connect.
+type SessionRequest struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Types that are valid to be assigned to Frame:
+	//	*SessionRequest_Connect
+	//	*SessionRequest_Ack
+	//	*SessionRequest_Send
+	//	*SessionRequest_Subscribe
+	//	*SessionRequest_Unsubscribe
+	//	*SessionRequest_Heartbeat
+	//	*SessionRequest_Disconnect
+	Frame                isSessionRequest_Frame `protobuf_oneof:"frame"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *SessionRequest) Reset()         { *m = SessionRequest{} }
+func (m *SessionRequest) String() string { return proto.CompactTextString(m) }
+func (*SessionRequest) ProtoMessage()    {}
+func (*SessionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{12}
+}
+
+func (m *SessionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SessionRequest.Unmarshal(m, b)
+}
+func (m *SessionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SessionRequest.Marshal(b, m, deterministic)
+}
+func (m *SessionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SessionRequest.Merge(m, src)
+}
+func (m *SessionRequest) XXX_Size() int {
+	return xxx_messageInfo_SessionRequest.Size(m)
+}
+func (m *SessionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SessionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SessionRequest proto.InternalMessageInfo
+
+func (m *SessionRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type isSessionRequest_Frame interface {
+	isSessionRequest_Frame()
+}
+
+type SessionRequest_Connect struct {
+	Connect *SessionConnect `protobuf:"bytes,2,opt,name=connect,proto3,oneof"`
+}
+
+type SessionRequest_Ack struct {
+	Ack *AckRequest `protobuf:"bytes,3,opt,name=ack,proto3,oneof"`
+}
+
+type SessionRequest_Send struct {
+	Send *UnicastRequest `protobuf:"bytes,4,opt,name=send,proto3,oneof"`
+}
+
+type SessionRequest_Subscribe struct {
+	Subscribe *SubscribeRequest `protobuf:"bytes,5,opt,name=subscribe,proto3,oneof"`
+}
+
+type SessionRequest_Unsubscribe struct {
+	Unsubscribe *UnsubscribeRequest `protobuf:"bytes,6,opt,name=unsubscribe,proto3,oneof"`
+}
+
+type SessionRequest_Heartbeat struct {
+	Heartbeat *HeartbeatRequest `protobuf:"bytes,7,opt,name=heartbeat,proto3,oneof"`
+}
+
+type SessionRequest_Disconnect struct {
+	Disconnect *DisconnectRequest `protobuf:"bytes,8,opt,name=disconnect,proto3,oneof"`
+}
+
+func (*SessionRequest_Connect) isSessionRequest_Frame() {}
+
+func (*SessionRequest_Ack) isSessionRequest_Frame() {}
+
+func (*SessionRequest_Send) isSessionRequest_Frame() {}
+
+func (*SessionRequest_Subscribe) isSessionRequest_Frame() {}
+
+func (*SessionRequest_Unsubscribe) isSessionRequest_Frame() {}
+
+func (*SessionRequest_Heartbeat) isSessionRequest_Frame() {}
+
+func (*SessionRequest_Disconnect) isSessionRequest_Frame() {}
+
+func (m *SessionRequest) GetFrame() isSessionRequest_Frame {
+	if m != nil {
+		return m.Frame
+	}
+	return nil
+}
+
+func (m *SessionRequest) GetConnect() *SessionConnect {
+	if x, ok := m.GetFrame().(*SessionRequest_Connect); ok {
+		return x.Connect
+	}
+	return nil
+}
+
+func (m *SessionRequest) GetAck() *AckRequest {
+	if x, ok := m.GetFrame().(*SessionRequest_Ack); ok {
+		return x.Ack
+	}
+	return nil
+}
+
+func (m *SessionRequest) GetSend() *UnicastRequest {
+	if x, ok := m.GetFrame().(*SessionRequest_Send); ok {
+		return x.Send
+	}
+	return nil
+}
+
+func (m *SessionRequest) GetSubscribe() *SubscribeRequest {
+	if x, ok := m.GetFrame().(*SessionRequest_Subscribe); ok {
+		return x.Subscribe
+	}
+	return nil
+}
+
+func (m *SessionRequest) GetUnsubscribe() *UnsubscribeRequest {
+	if x, ok := m.GetFrame().(*SessionRequest_Unsubscribe); ok {
+		return x.Unsubscribe
+	}
+	return nil
+}
+
+func (m *SessionRequest) GetHeartbeat() *HeartbeatRequest {
+	if x, ok := m.GetFrame().(*SessionRequest_Heartbeat); ok {
+		return x.Heartbeat
+	}
+	return nil
+}
+
+func (m *SessionRequest) GetDisconnect() *DisconnectRequest {
+	if x, ok := m.GetFrame().(*SessionRequest_Disconnect); ok {
+		return x.Disconnect
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*SessionRequest) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*SessionRequest_Connect)(nil),
+		(*SessionRequest_Ack)(nil),
+		(*SessionRequest_Send)(nil),
+		(*SessionRequest_Subscribe)(nil),
+		(*SessionRequest_Unsubscribe)(nil),
+		(*SessionRequest_Heartbeat)(nil),
+		(*SessionRequest_Disconnect)(nil),
+	}
+}
+
+type SessionResult struct {
+	Errcode              ErrorCode `protobuf:"varint,1,opt,name=errcode,proto3,enum=sims.proto.ErrorCode" json:"errcode,omitempty"`
+	Error                string    `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *SessionResult) Reset()         { *m = SessionResult{} }
+func (m *SessionResult) String() string { return proto.CompactTextString(m) }
+func (*SessionResult) ProtoMessage()    {}
+func (*SessionResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{13}
+}
+
+func (m *SessionResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SessionResult.Unmarshal(m, b)
+}
+func (m *SessionResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SessionResult.Marshal(b, m, deterministic)
+}
+func (m *SessionResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SessionResult.Merge(m, src)
+}
+func (m *SessionResult) XXX_Size() int {
+	return xxx_messageInfo_SessionResult.Size(m)
+}
+func (m *SessionResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_SessionResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SessionResult proto.InternalMessageInfo
+
+func (m *SessionResult) GetErrcode() ErrorCode {
+	if m != nil {
+		return m.Errcode
+	}
+	return ErrorCode_ERR_UNSPECIFIED
+}
+
+func (m *SessionResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// SessionResponse is a frame from the server: an event, or the result of a client frame of the same id
+type SessionResponse struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Types that are valid to be assigned to Frame:
+	//	*SessionResponse_Event
+	//	*SessionResponse_Connected
+	//	*SessionResponse_Result
+	Frame                isSessionResponse_Frame `protobuf_oneof:"frame"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *SessionResponse) Reset()         { *m = SessionResponse{} }
+func (m *SessionResponse) String() string { return proto.CompactTextString(m) }
+func (*SessionResponse) ProtoMessage()    {}
+func (*SessionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{14}
+}
+
+func (m *SessionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SessionResponse.Unmarshal(m, b)
+}
+func (m *SessionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SessionResponse.Marshal(b, m, deterministic)
+}
+func (m *SessionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SessionResponse.Merge(m, src)
+}
+func (m *SessionResponse) XXX_Size() int {
+	return xxx_messageInfo_SessionResponse.Size(m)
+}
+func (m *SessionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SessionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SessionResponse proto.InternalMessageInfo
+
+func (m *SessionResponse) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type isSessionResponse_Frame interface {
+	isSessionResponse_Frame()
+}
+
+type SessionResponse_Event struct {
+	Event *Event `protobuf:"bytes,2,opt,name=event,proto3,oneof"`
+}
+
+type SessionResponse_Connected struct {
+	Connected *ConnectResponse `protobuf:"bytes,3,opt,name=connected,proto3,oneof"`
+}
+
+type SessionResponse_Result struct {
+	Result *SessionResult `protobuf:"bytes,4,opt,name=result,proto3,oneof"`
+}
+
+func (*SessionResponse_Event) isSessionResponse_Frame() {}
+
+func (*SessionResponse_Connected) isSessionResponse_Frame() {}
+
+func (*SessionResponse_Result) isSessionResponse_Frame() {}
+
+func (m *SessionResponse) GetFrame() isSessionResponse_Frame {
+	if m != nil {
+		return m.Frame
+	}
+	return nil
+}
+
+func (m *SessionResponse) GetEvent() *Event {
+	if x, ok := m.GetFrame().(*SessionResponse_Event); ok {
+		return x.Event
+	}
+	return nil
+}
+
+func (m *SessionResponse) GetConnected() *ConnectResponse {
+	if x, ok := m.GetFrame().(*SessionResponse_Connected); ok {
+		return x.Connected
+	}
+	return nil
+}
+
+func (m *SessionResponse) GetResult() *SessionResult {
+	if x, ok := m.GetFrame().(*SessionResponse_Result); ok {
+		return x.Result
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*SessionResponse) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*SessionResponse_Event)(nil),
+		(*SessionResponse_Connected)(nil),
+		(*SessionResponse_Result)(nil),
+	}
+}
+
+type DisconnectRequest struct {
+	Header               *Header  `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DisconnectRequest) Reset()         { *m = DisconnectRequest{} }
+func (m *DisconnectRequest) String() string { return proto.CompactTextString(m) }
+func (*DisconnectRequest) ProtoMessage()    {}
+func (*DisconnectRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{15}
+}
+
+func (m *DisconnectRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DisconnectRequest.Unmarshal(m, b)
+}
+func (m *DisconnectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DisconnectRequest.Marshal(b, m, deterministic)
+}
+func (m *DisconnectRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DisconnectRequest.Merge(m, src)
+}
+func (m *DisconnectRequest) XXX_Size() int {
+	return xxx_messageInfo_DisconnectRequest.Size(m)
+}
+func (m *DisconnectRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DisconnectRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DisconnectRequest proto.InternalMessageInfo
+
+func (m *DisconnectRequest) GetHeader() *Header {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+type DisconnectResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DisconnectResponse) Reset()         { *m = DisconnectResponse{} }
+func (m *DisconnectResponse) String() string { return proto.CompactTextString(m) }
+func (*DisconnectResponse) ProtoMessage()    {}
+func (*DisconnectResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{16}
+}
+
+func (m *DisconnectResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DisconnectResponse.Unmarshal(m, b)
+}
+func (m *DisconnectResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DisconnectResponse.Marshal(b, m, deterministic)
+}
+func (m *DisconnectResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DisconnectResponse.Merge(m, src)
+}
+func (m *DisconnectResponse) XXX_Size() int {
+	return xxx_messageInfo_DisconnectResponse.Size(m)
+}
+func (m *DisconnectResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DisconnectResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DisconnectResponse proto.InternalMessageInfo
+
+type UnicastRequest struct {
+	UserId               string    `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Event                *Event    `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`
+	UserSelector         *Selector `protobuf:"bytes,3,opt,name=user_selector,json=userSelector,proto3" json:"user_selector,omitempty"`
+	RequestReceipt       bool      `protobuf:"varint,4,opt,name=request_receipt,json=requestReceipt,proto3" json:"request_receipt,omitempty"`
+	DeliverAt            int64     `protobuf:"varint,5,opt,name=deliver_at,json=deliverAt,proto3" json:"deliver_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *UnicastRequest) Reset()         { *m = UnicastRequest{} }
+func (m *UnicastRequest) String() string { return proto.CompactTextString(m) }
+func (*UnicastRequest) ProtoMessage()    {}
+func (*UnicastRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{17}
+}
+
+func (m *UnicastRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnicastRequest.Unmarshal(m, b)
+}
+func (m *UnicastRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnicastRequest.Marshal(b, m, deterministic)
+}
+func (m *UnicastRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnicastRequest.Merge(m, src)
+}
+func (m *UnicastRequest) XXX_Size() int {
+	return xxx_messageInfo_UnicastRequest.Size(m)
+}
+func (m *UnicastRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnicastRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnicastRequest proto.InternalMessageInfo
+
+func (m *UnicastRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *UnicastRequest) GetEvent() *Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (m *UnicastRequest) GetUserSelector() *Selector {
+	if m != nil {
+		return m.UserSelector
+	}
+	return nil
+}
+
+func (m *UnicastRequest) GetRequestReceipt() bool {
+	if m != nil {
+		return m.RequestReceipt
+	}
+	return false
+}
+
+func (m *UnicastRequest) GetDeliverAt() int64 {
+	if m != nil {
+		return m.DeliverAt
+	}
+	return 0
+}
+
+type UnicastResponse struct {
+	EventId              string   `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	ScheduleId           string   `protobuf:"bytes,2,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	TraceId              string   `protobuf:"bytes,3,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnicastResponse) Reset()         { *m = UnicastResponse{} }
+func (m *UnicastResponse) String() string { return proto.CompactTextString(m) }
+func (*UnicastResponse) ProtoMessage()    {}
+func (*UnicastResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{18}
+}
+
+func (m *UnicastResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnicastResponse.Unmarshal(m, b)
+}
+func (m *UnicastResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnicastResponse.Marshal(b, m, deterministic)
+}
+func (m *UnicastResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnicastResponse.Merge(m, src)
+}
+func (m *UnicastResponse) XXX_Size() int {
+	return xxx_messageInfo_UnicastResponse.Size(m)
+}
+func (m *UnicastResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnicastResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnicastResponse proto.InternalMessageInfo
+
+func (m *UnicastResponse) GetEventId() string {
+	if m != nil {
+		return m.EventId
+	}
+	return ""
+}
+
+func (m *UnicastResponse) GetScheduleId() string {
+	if m != nil {
+		return m.ScheduleId
+	}
+	return ""
+}
+
+func (m *UnicastResponse) GetTraceId() string {
+	if m != nil {
+		return m.TraceId
+	}
+	return ""
+}
+
+type Receipt struct {
+	EventId              string        `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	UserId               string        `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DeviceId             string        `protobuf:"bytes,3,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Status               ReceiptStatus `protobuf:"varint,4,opt,name=status,proto3,enum=sims.proto.ReceiptStatus" json:"status,omitempty"`
+	Timestamp            int64         `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Publisher            string        `protobuf:"bytes,6,opt,name=publisher,proto3" json:"publisher,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *Receipt) Reset()         { *m = Receipt{} }
+func (m *Receipt) String() string { return proto.CompactTextString(m) }
+func (*Receipt) ProtoMessage()    {}
+func (*Receipt) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{19}
+}
+
+func (m *Receipt) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Receipt.Unmarshal(m, b)
+}
+func (m *Receipt) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Receipt.Marshal(b, m, deterministic)
+}
+func (m *Receipt) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Receipt.Merge(m, src)
+}
+func (m *Receipt) XXX_Size() int {
+	return xxx_messageInfo_Receipt.Size(m)
+}
+func (m *Receipt) XXX_DiscardUnknown() {
+	xxx_messageInfo_Receipt.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Receipt proto.InternalMessageInfo
+
+func (m *Receipt) GetEventId() string {
+	if m != nil {
+		return m.EventId
+	}
+	return ""
+}
+
+func (m *Receipt) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *Receipt) GetDeviceId() string {
+	if m != nil {
+		return m.DeviceId
+	}
+	return ""
+}
+
+func (m *Receipt) GetStatus() ReceiptStatus {
+	if m != nil {
+		return m.Status
+	}
+	return ReceiptStatus_RECEIPT_DELIVERED
+}
+
+func (m *Receipt) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *Receipt) GetPublisher() string {
+	if m != nil {
+		return m.Publisher
+	}
+	return ""
+}
+
+type ReceiptsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReceiptsRequest) Reset()         { *m = ReceiptsRequest{} }
+func (m *ReceiptsRequest) String() string { return proto.CompactTextString(m) }
+func (*ReceiptsRequest) ProtoMessage()    {}
+func (*ReceiptsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{20}
+}
+
+func (m *ReceiptsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReceiptsRequest.Unmarshal(m, b)
+}
+func (m *ReceiptsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReceiptsRequest.Marshal(b, m, deterministic)
+}
+func (m *ReceiptsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReceiptsRequest.Merge(m, src)
+}
+func (m *ReceiptsRequest) XXX_Size() int {
+	return xxx_messageInfo_ReceiptsRequest.Size(m)
+}
+func (m *ReceiptsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReceiptsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReceiptsRequest proto.InternalMessageInfo
+
+type MulticastRequest struct {
+	UserId               []string             `protobuf:"bytes,1,rep,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Event                *Event               `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`
+	UserSelector         map[string]*Selector `protobuf:"bytes,3,rep,name=user_selector,json=userSelector,proto3" json:"user_selector,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	DeliverAt            int64                `protobuf:"varint,4,opt,name=deliver_at,json=deliverAt,proto3" json:"deliver_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *MulticastRequest) Reset()         { *m = MulticastRequest{} }
+func (m *MulticastRequest) String() string { return proto.CompactTextString(m) }
+func (*MulticastRequest) ProtoMessage()    {}
+func (*MulticastRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{21}
+}
+
+func (m *MulticastRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MulticastRequest.Unmarshal(m, b)
+}
+func (m *MulticastRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MulticastRequest.Marshal(b, m, deterministic)
+}
+func (m *MulticastRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MulticastRequest.Merge(m, src)
+}
+func (m *MulticastRequest) XXX_Size() int {
+	return xxx_messageInfo_MulticastRequest.Size(m)
+}
+func (m *MulticastRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MulticastRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MulticastRequest proto.InternalMessageInfo
+
+func (m *MulticastRequest) GetUserId() []string {
+	if m != nil {
+		return m.UserId
+	}
+	return nil
+}
+
+func (m *MulticastRequest) GetEvent() *Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (m *MulticastRequest) GetUserSelector() map[string]*Selector {
+	if m != nil {
+		return m.UserSelector
+	}
+	return nil
+}
+
+func (m *MulticastRequest) GetDeliverAt() int64 {
+	if m != nil {
+		return m.DeliverAt
+	}
+	return 0
+}
+
+type MulticastResponse struct {
+	UserErrcode          map[string]ErrorCode `protobuf:"bytes,1,rep,name=user_errcode,json=userErrcode,proto3" json:"user_errcode,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3,enum=sims.proto.ErrorCode"`
+	ScheduleId           string               `protobuf:"bytes,2,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *MulticastResponse) Reset()         { *m = MulticastResponse{} }
+func (m *MulticastResponse) String() string { return proto.CompactTextString(m) }
+func (*MulticastResponse) ProtoMessage()    {}
+func (*MulticastResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{22}
+}
+
+func (m *MulticastResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MulticastResponse.Unmarshal(m, b)
+}
+func (m *MulticastResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MulticastResponse.Marshal(b, m, deterministic)
+}
+func (m *MulticastResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MulticastResponse.Merge(m, src)
+}
+func (m *MulticastResponse) XXX_Size() int {
+	return xxx_messageInfo_MulticastResponse.Size(m)
+}
+func (m *MulticastResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MulticastResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MulticastResponse proto.InternalMessageInfo
+
+func (m *MulticastResponse) GetUserErrcode() map[string]ErrorCode {
+	if m != nil {
+		return m.UserErrcode
+	}
+	return nil
+}
+
+func (m *MulticastResponse) GetScheduleId() string {
+	if m != nil {
+		return m.ScheduleId
+	}
+	return ""
+}
+
+type CancelRequest struct {
+	ScheduleId           string   `protobuf:"bytes,1,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelRequest) Reset()         { *m = CancelRequest{} }
+func (m *CancelRequest) String() string { return proto.CompactTextString(m) }
+func (*CancelRequest) ProtoMessage()    {}
+func (*CancelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{23}
+}
+
+func (m *CancelRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelRequest.Unmarshal(m, b)
+}
+func (m *CancelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelRequest.Marshal(b, m, deterministic)
+}
+func (m *CancelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelRequest.Merge(m, src)
+}
+func (m *CancelRequest) XXX_Size() int {
+	return xxx_messageInfo_CancelRequest.Size(m)
+}
+func (m *CancelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelRequest proto.InternalMessageInfo
+
+func (m *CancelRequest) GetScheduleId() string {
+	if m != nil {
+		return m.ScheduleId
+	}
+	return ""
+}
+
+type CancelResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelResponse) Reset()         { *m = CancelResponse{} }
+func (m *CancelResponse) String() string { return proto.CompactTextString(m) }
+func (*CancelResponse) ProtoMessage()    {}
+func (*CancelResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{24}
+}
+
+func (m *CancelResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelResponse.Unmarshal(m, b)
+}
+func (m *CancelResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelResponse.Marshal(b, m, deterministic)
+}
+func (m *CancelResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelResponse.Merge(m, src)
+}
+func (m *CancelResponse) XXX_Size() int {
+	return xxx_messageInfo_CancelResponse.Size(m)
+}
+func (m *CancelResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelResponse proto.InternalMessageInfo
+
+// Schedule is a delivery scheduled by deliver_at, kept in the store until it is due
+type Schedule struct {
+	Id                   string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DeliverAt            int64             `protobuf:"varint,2,opt,name=deliver_at,json=deliverAt,proto3" json:"deliver_at,omitempty"`
+	Unicast              *UnicastRequest   `protobuf:"bytes,3,opt,name=unicast,proto3" json:"unicast,omitempty"`
+	Multicast            *MulticastRequest `protobuf:"bytes,4,opt,name=multicast,proto3" json:"multicast,omitempty"`
+	AccountId            string            `protobuf:"bytes,5,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	AccountScopes        []string          `protobuf:"bytes,6,rep,name=account_scopes,json=accountScopes,proto3" json:"account_scopes,omitempty"`
+	Publisher            string            `protobuf:"bytes,7,opt,name=publisher,proto3" json:"publisher,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Schedule) Reset()         { *m = Schedule{} }
+func (m *Schedule) String() string { return proto.CompactTextString(m) }
+func (*Schedule) ProtoMessage()    {}
+func (*Schedule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{25}
+}
+
+func (m *Schedule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Schedule.Unmarshal(m, b)
+}
+func (m *Schedule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Schedule.Marshal(b, m, deterministic)
+}
+func (m *Schedule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Schedule.Merge(m, src)
+}
+func (m *Schedule) XXX_Size() int {
+	return xxx_messageInfo_Schedule.Size(m)
+}
+func (m *Schedule) XXX_DiscardUnknown() {
+	xxx_messageInfo_Schedule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Schedule proto.InternalMessageInfo
+
+func (m *Schedule) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Schedule) GetDeliverAt() int64 {
+	if m != nil {
+		return m.DeliverAt
+	}
+	return 0
+}
+
+func (m *Schedule) GetUnicast() *UnicastRequest {
+	if m != nil {
+		return m.Unicast
+	}
+	return nil
+}
+
+func (m *Schedule) GetMulticast() *MulticastRequest {
+	if m != nil {
+		return m.Multicast
+	}
+	return nil
+}
+
+func (m *Schedule) GetAccountId() string {
+	if m != nil {
+		return m.AccountId
+	}
+	return ""
+}
+
+func (m *Schedule) GetAccountScopes() []string {
+	if m != nil {
+		return m.AccountScopes
+	}
+	return nil
+}
+
+func (m *Schedule) GetPublisher() string {
+	if m != nil {
+		return m.Publisher
+	}
+	return ""
+}
+
+type PublishRequest struct {
+	Topic                string   `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	Event                *Event   `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PublishRequest) Reset()         { *m = PublishRequest{} }
+func (m *PublishRequest) String() string { return proto.CompactTextString(m) }
+func (*PublishRequest) ProtoMessage()    {}
+func (*PublishRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{26}
+}
+
+func (m *PublishRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PublishRequest.Unmarshal(m, b)
+}
+func (m *PublishRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PublishRequest.Marshal(b, m, deterministic)
+}
+func (m *PublishRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublishRequest.Merge(m, src)
+}
+func (m *PublishRequest) XXX_Size() int {
+	return xxx_messageInfo_PublishRequest.Size(m)
+}
+func (m *PublishRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublishRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublishRequest proto.InternalMessageInfo
+
+func (m *PublishRequest) GetTopic() string {
+	if m != nil {
+		return m.Topic
+	}
+	return ""
+}
+
+func (m *PublishRequest) GetEvent() *Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+type PublishResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PublishResponse) Reset()         { *m = PublishResponse{} }
+func (m *PublishResponse) String() string { return proto.CompactTextString(m) }
+func (*PublishResponse) ProtoMessage()    {}
+func (*PublishResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{27}
+}
+
+func (m *PublishResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PublishResponse.Unmarshal(m, b)
+}
+func (m *PublishResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PublishResponse.Marshal(b, m, deterministic)
+}
+func (m *PublishResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublishResponse.Merge(m, src)
+}
+func (m *PublishResponse) XXX_Size() int {
+	return xxx_messageInfo_PublishResponse.Size(m)
+}
+func (m *PublishResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublishResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublishResponse proto.InternalMessageInfo
+
+type SubscribeRequest struct {
+	Header               *Header  `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Topic                string   `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscribeRequest) Reset()         { *m = SubscribeRequest{} }
+func (m *SubscribeRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeRequest) ProtoMessage()    {}
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{28}
+}
+
+func (m *SubscribeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeRequest.Unmarshal(m, b)
+}
+func (m *SubscribeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeRequest.Marshal(b, m, deterministic)
+}
+func (m *SubscribeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeRequest.Merge(m, src)
+}
+func (m *SubscribeRequest) XXX_Size() int {
+	return xxx_messageInfo_SubscribeRequest.Size(m)
+}
+func (m *SubscribeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeRequest proto.InternalMessageInfo
+
+func (m *SubscribeRequest) GetHeader() *Header {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *SubscribeRequest) GetTopic() string {
+	if m != nil {
+		return m.Topic
+	}
+	return ""
+}
+
+type SubscribeResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscribeResponse) Reset()         { *m = SubscribeResponse{} }
+func (m *SubscribeResponse) String() string { return proto.CompactTextString(m) }
+func (*SubscribeResponse) ProtoMessage()    {}
+func (*SubscribeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{29}
+}
+
+func (m *SubscribeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeResponse.Unmarshal(m, b)
+}
+func (m *SubscribeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeResponse.Marshal(b, m, deterministic)
+}
+func (m *SubscribeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeResponse.Merge(m, src)
+}
+func (m *SubscribeResponse) XXX_Size() int {
+	return xxx_messageInfo_SubscribeResponse.Size(m)
+}
+func (m *SubscribeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeResponse proto.InternalMessageInfo
+
+type UnsubscribeRequest struct {
+	Header               *Header  `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Topic                string   `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnsubscribeRequest) Reset()         { *m = UnsubscribeRequest{} }
+func (m *UnsubscribeRequest) String() string { return proto.CompactTextString(m) }
+func (*UnsubscribeRequest) ProtoMessage()    {}
+func (*UnsubscribeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{30}
+}
+
+func (m *UnsubscribeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnsubscribeRequest.Unmarshal(m, b)
+}
+func (m *UnsubscribeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnsubscribeRequest.Marshal(b, m, deterministic)
+}
+func (m *UnsubscribeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnsubscribeRequest.Merge(m, src)
+}
+func (m *UnsubscribeRequest) XXX_Size() int {
+	return xxx_messageInfo_UnsubscribeRequest.Size(m)
+}
+func (m *UnsubscribeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnsubscribeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnsubscribeRequest proto.InternalMessageInfo
+
+func (m *UnsubscribeRequest) GetHeader() *Header {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *UnsubscribeRequest) GetTopic() string {
+	if m != nil {
+		return m.Topic
+	}
+	return ""
+}
+
+type UnsubscribeResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnsubscribeResponse) Reset()         { *m = UnsubscribeResponse{} }
+func (m *UnsubscribeResponse) String() string { return proto.CompactTextString(m) }
+func (*UnsubscribeResponse) ProtoMessage()    {}
+func (*UnsubscribeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{31}
+}
+
+func (m *UnsubscribeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnsubscribeResponse.Unmarshal(m, b)
+}
+func (m *UnsubscribeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnsubscribeResponse.Marshal(b, m, deterministic)
+}
+func (m *UnsubscribeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnsubscribeResponse.Merge(m, src)
+}
+func (m *UnsubscribeResponse) XXX_Size() int {
+	return xxx_messageInfo_UnsubscribeResponse.Size(m)
+}
+func (m *UnsubscribeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnsubscribeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnsubscribeResponse proto.InternalMessageInfo
+
+type UserPresence struct {
+	UserId               string         `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status               PresenceStatus `protobuf:"varint,2,opt,name=status,proto3,enum=sims.proto.PresenceStatus" json:"status,omitempty"`
+	LastSeen             int64          `protobuf:"varint,3,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	DeviceId             []string       `protobuf:"bytes,4,rep,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *UserPresence) Reset()         { *m = UserPresence{} }
+func (m *UserPresence) String() string { return proto.CompactTextString(m) }
+func (*UserPresence) ProtoMessage()    {}
+func (*UserPresence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{32}
+}
+
+func (m *UserPresence) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UserPresence.Unmarshal(m, b)
+}
+func (m *UserPresence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UserPresence.Marshal(b, m, deterministic)
+}
+func (m *UserPresence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UserPresence.Merge(m, src)
+}
+func (m *UserPresence) XXX_Size() int {
+	return xxx_messageInfo_UserPresence.Size(m)
+}
+func (m *UserPresence) XXX_DiscardUnknown() {
+	xxx_messageInfo_UserPresence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UserPresence proto.InternalMessageInfo
+
+func (m *UserPresence) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *UserPresence) GetStatus() PresenceStatus {
+	if m != nil {
+		return m.Status
+	}
+	return PresenceStatus_PRESENCE_OFFLINE
+}
+
+func (m *UserPresence) GetLastSeen() int64 {
+	if m != nil {
+		return m.LastSeen
+	}
+	return 0
+}
+
+func (m *UserPresence) GetDeviceId() []string {
+	if m != nil {
+		return m.DeviceId
+	}
+	return nil
+}
+
+type GetPresenceRequest struct {
+	UserId               []string `protobuf:"bytes,1,rep,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPresenceRequest) Reset()         { *m = GetPresenceRequest{} }
+func (m *GetPresenceRequest) String() string { return proto.CompactTextString(m) }
+func (*GetPresenceRequest) ProtoMessage()    {}
+func (*GetPresenceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{33}
+}
+
+func (m *GetPresenceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPresenceRequest.Unmarshal(m, b)
+}
+func (m *GetPresenceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPresenceRequest.Marshal(b, m, deterministic)
+}
+func (m *GetPresenceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPresenceRequest.Merge(m, src)
+}
+func (m *GetPresenceRequest) XXX_Size() int {
+	return xxx_messageInfo_GetPresenceRequest.Size(m)
+}
+func (m *GetPresenceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPresenceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPresenceRequest proto.InternalMessageInfo
+
+func (m *GetPresenceRequest) GetUserId() []string {
+	if m != nil {
+		return m.UserId
+	}
+	return nil
+}
+
+type GetPresenceResponse struct {
+	Presence             map[string]*UserPresence `protobuf:"bytes,1,rep,name=presence,proto3" json:"presence,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *GetPresenceResponse) Reset()         { *m = GetPresenceResponse{} }
+func (m *GetPresenceResponse) String() string { return proto.CompactTextString(m) }
+func (*GetPresenceResponse) ProtoMessage()    {}
+func (*GetPresenceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{34}
+}
+
+func (m *GetPresenceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPresenceResponse.Unmarshal(m, b)
+}
+func (m *GetPresenceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPresenceResponse.Marshal(b, m, deterministic)
+}
+func (m *GetPresenceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPresenceResponse.Merge(m, src)
+}
+func (m *GetPresenceResponse) XXX_Size() int {
+	return xxx_messageInfo_GetPresenceResponse.Size(m)
+}
+func (m *GetPresenceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPresenceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPresenceResponse proto.InternalMessageInfo
+
+func (m *GetPresenceResponse) GetPresence() map[string]*UserPresence {
+	if m != nil {
+		return m.Presence
+	}
+	return nil
+}
+
+type WatchPresenceRequest struct {
+	UserId               []string `protobuf:"bytes,1,rep,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchPresenceRequest) Reset()         { *m = WatchPresenceRequest{} }
+func (m *WatchPresenceRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchPresenceRequest) ProtoMessage()    {}
+func (*WatchPresenceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{35}
+}
+
+func (m *WatchPresenceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchPresenceRequest.Unmarshal(m, b)
+}
+func (m *WatchPresenceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchPresenceRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchPresenceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchPresenceRequest.Merge(m, src)
+}
+func (m *WatchPresenceRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchPresenceRequest.Size(m)
+}
+func (m *WatchPresenceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchPresenceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchPresenceRequest proto.InternalMessageInfo
+
+func (m *WatchPresenceRequest) GetUserId() []string {
+	if m != nil {
+		return m.UserId
+	}
+	return nil
+}
+
+type FetchRequest struct {
+	Conversation         string   `protobuf:"bytes,1,opt,name=conversation,proto3" json:"conversation,omitempty"`
+	Before               string   `protobuf:"bytes,2,opt,name=before,proto3" json:"before,omitempty"`
+	Limit                int32    `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FetchRequest) Reset()         { *m = FetchRequest{} }
+func (m *FetchRequest) String() string { return proto.CompactTextString(m) }
+func (*FetchRequest) ProtoMessage()    {}
+func (*FetchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{36}
+}
+
+func (m *FetchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchRequest.Unmarshal(m, b)
+}
+func (m *FetchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchRequest.Marshal(b, m, deterministic)
+}
+func (m *FetchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchRequest.Merge(m, src)
+}
+func (m *FetchRequest) XXX_Size() int {
+	return xxx_messageInfo_FetchRequest.Size(m)
+}
+func (m *FetchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchRequest proto.InternalMessageInfo
+
+func (m *FetchRequest) GetConversation() string {
+	if m != nil {
+		return m.Conversation
+	}
+	return ""
+}
+
+func (m *FetchRequest) GetBefore() string {
+	if m != nil {
+		return m.Before
+	}
+	return ""
+}
+
+func (m *FetchRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type FetchResponse struct {
+	Event                []*Event `protobuf:"bytes,1,rep,name=event,proto3" json:"event,omitempty"`
+	Before               string   `protobuf:"bytes,2,opt,name=before,proto3" json:"before,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FetchResponse) Reset()         { *m = FetchResponse{} }
+func (m *FetchResponse) String() string { return proto.CompactTextString(m) }
+func (*FetchResponse) ProtoMessage()    {}
+func (*FetchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{37}
+}
+
+func (m *FetchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchResponse.Unmarshal(m, b)
+}
+func (m *FetchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchResponse.Marshal(b, m, deterministic)
+}
+func (m *FetchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchResponse.Merge(m, src)
+}
+func (m *FetchResponse) XXX_Size() int {
+	return xxx_messageInfo_FetchResponse.Size(m)
+}
+func (m *FetchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchResponse proto.InternalMessageInfo
+
+func (m *FetchResponse) GetEvent() []*Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (m *FetchResponse) GetBefore() string {
+	if m != nil {
+		return m.Before
+	}
+	return ""
+}
+
+type KickRequest struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DeviceId             string   `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Reason               string   `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	BannedUntil          int64    `protobuf:"varint,4,opt,name=banned_until,json=bannedUntil,proto3" json:"banned_until,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KickRequest) Reset()         { *m = KickRequest{} }
+func (m *KickRequest) String() string { return proto.CompactTextString(m) }
+func (*KickRequest) ProtoMessage()    {}
+func (*KickRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{38}
+}
+
+func (m *KickRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KickRequest.Unmarshal(m, b)
+}
+func (m *KickRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KickRequest.Marshal(b, m, deterministic)
+}
+func (m *KickRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KickRequest.Merge(m, src)
+}
+func (m *KickRequest) XXX_Size() int {
+	return xxx_messageInfo_KickRequest.Size(m)
+}
+func (m *KickRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_KickRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KickRequest proto.InternalMessageInfo
+
+func (m *KickRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *KickRequest) GetDeviceId() string {
+	if m != nil {
+		return m.DeviceId
+	}
+	return ""
+}
+
+func (m *KickRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *KickRequest) GetBannedUntil() int64 {
+	if m != nil {
+		return m.BannedUntil
+	}
+	return 0
+}
+
+type KickResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KickResponse) Reset()         { *m = KickResponse{} }
+func (m *KickResponse) String() string { return proto.CompactTextString(m) }
+func (*KickResponse) ProtoMessage()    {}
+func (*KickResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{39}
+}
+
+func (m *KickResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KickResponse.Unmarshal(m, b)
+}
+func (m *KickResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KickResponse.Marshal(b, m, deterministic)
+}
+func (m *KickResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KickResponse.Merge(m, src)
+}
+func (m *KickResponse) XXX_Size() int {
+	return xxx_messageInfo_KickResponse.Size(m)
+}
+func (m *KickResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_KickResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KickResponse proto.InternalMessageInfo
+
+type BanRequest struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Until                int64    `protobuf:"varint,2,opt,name=until,proto3" json:"until,omitempty"`
+	Reason               string   `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BanRequest) Reset()         { *m = BanRequest{} }
+func (m *BanRequest) String() string { return proto.CompactTextString(m) }
+func (*BanRequest) ProtoMessage()    {}
+func (*BanRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{40}
+}
+
+func (m *BanRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BanRequest.Unmarshal(m, b)
+}
+func (m *BanRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BanRequest.Marshal(b, m, deterministic)
+}
+func (m *BanRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BanRequest.Merge(m, src)
+}
+func (m *BanRequest) XXX_Size() int {
+	return xxx_messageInfo_BanRequest.Size(m)
+}
+func (m *BanRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BanRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BanRequest proto.InternalMessageInfo
+
+func (m *BanRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *BanRequest) GetUntil() int64 {
+	if m != nil {
+		return m.Until
+	}
+	return 0
+}
+
+func (m *BanRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type BanResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BanResponse) Reset()         { *m = BanResponse{} }
+func (m *BanResponse) String() string { return proto.CompactTextString(m) }
+func (*BanResponse) ProtoMessage()    {}
+func (*BanResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{41}
+}
+
+func (m *BanResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BanResponse.Unmarshal(m, b)
+}
+func (m *BanResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BanResponse.Marshal(b, m, deterministic)
+}
+func (m *BanResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BanResponse.Merge(m, src)
+}
+func (m *BanResponse) XXX_Size() int {
+	return xxx_messageInfo_BanResponse.Size(m)
+}
+func (m *BanResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BanResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BanResponse proto.InternalMessageInfo
+
+type Ban struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Until                int64    `protobuf:"varint,2,opt,name=until,proto3" json:"until,omitempty"`
+	Reason               string   `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Ban) Reset()         { *m = Ban{} }
+func (m *Ban) String() string { return proto.CompactTextString(m) }
+func (*Ban) ProtoMessage()    {}
+func (*Ban) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{42}
+}
+
+func (m *Ban) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Ban.Unmarshal(m, b)
+}
+func (m *Ban) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Ban.Marshal(b, m, deterministic)
+}
+func (m *Ban) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Ban.Merge(m, src)
+}
+func (m *Ban) XXX_Size() int {
+	return xxx_messageInfo_Ban.Size(m)
+}
+func (m *Ban) XXX_DiscardUnknown() {
+	xxx_messageInfo_Ban.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Ban proto.InternalMessageInfo
+
+func (m *Ban) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *Ban) GetUntil() int64 {
+	if m != nil {
+		return m.Until
+	}
+	return 0
+}
+
+func (m *Ban) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type ListBansRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListBansRequest) Reset()         { *m = ListBansRequest{} }
+func (m *ListBansRequest) String() string { return proto.CompactTextString(m) }
+func (*ListBansRequest) ProtoMessage()    {}
+func (*ListBansRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{43}
+}
+
+func (m *ListBansRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListBansRequest.Unmarshal(m, b)
+}
+func (m *ListBansRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListBansRequest.Marshal(b, m, deterministic)
+}
+func (m *ListBansRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListBansRequest.Merge(m, src)
+}
+func (m *ListBansRequest) XXX_Size() int {
+	return xxx_messageInfo_ListBansRequest.Size(m)
+}
+func (m *ListBansRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListBansRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListBansRequest proto.InternalMessageInfo
+
+type ListBansResponse struct {
+	Bans                 []*Ban   `protobuf:"bytes,1,rep,name=bans,proto3" json:"bans,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListBansResponse) Reset()         { *m = ListBansResponse{} }
+func (m *ListBansResponse) String() string { return proto.CompactTextString(m) }
+func (*ListBansResponse) ProtoMessage()    {}
+func (*ListBansResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{44}
+}
+
+func (m *ListBansResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListBansResponse.Unmarshal(m, b)
+}
+func (m *ListBansResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListBansResponse.Marshal(b, m, deterministic)
+}
+func (m *ListBansResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListBansResponse.Merge(m, src)
+}
+func (m *ListBansResponse) XXX_Size() int {
+	return xxx_messageInfo_ListBansResponse.Size(m)
+}
+func (m *ListBansResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListBansResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListBansResponse proto.InternalMessageInfo
+
+func (m *ListBansResponse) GetBans() []*Ban {
+	if m != nil {
+		return m.Bans
+	}
+	return nil
+}
+
+// Notification is posted to the hooks in JSON
+type Notification struct {
+	Id                   string    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Event                HookEvent `protobuf:"varint,2,opt,name=event,proto3,enum=sims.proto.HookEvent" json:"event,omitempty"`
+	UserId               string    `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DeviceId             string    `protobuf:"bytes,4,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Timestamp            int64     `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Node                 string    `protobuf:"bytes,6,opt,name=node,proto3" json:"node,omitempty"`
+	EventId              string    `protobuf:"bytes,7,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Reason               string    `protobuf:"bytes,8,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *Notification) Reset()         { *m = Notification{} }
+func (m *Notification) String() string { return proto.CompactTextString(m) }
+func (*Notification) ProtoMessage()    {}
+func (*Notification) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{45}
+}
+
+func (m *Notification) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Notification.Unmarshal(m, b)
+}
+func (m *Notification) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Notification.Marshal(b, m, deterministic)
+}
+func (m *Notification) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Notification.Merge(m, src)
+}
+func (m *Notification) XXX_Size() int {
+	return xxx_messageInfo_Notification.Size(m)
+}
+func (m *Notification) XXX_DiscardUnknown() {
+	xxx_messageInfo_Notification.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Notification proto.InternalMessageInfo
+
+func (m *Notification) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Notification) GetEvent() HookEvent {
+	if m != nil {
+		return m.Event
+	}
+	return HookEvent_HOOK_CONNECTED
+}
+
+func (m *Notification) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *Notification) GetDeviceId() string {
+	if m != nil {
+		return m.DeviceId
+	}
+	return ""
+}
+
+func (m *Notification) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *Notification) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
+func (m *Notification) GetEventId() string {
+	if m != nil {
+		return m.EventId
+	}
+	return ""
+}
+
+func (m *Notification) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+// DeadLetter is a notification failed on a hook after all attempts
+type DeadLetter struct {
+	Notification         *Notification `protobuf:"bytes,1,opt,name=notification,proto3" json:"notification,omitempty"`
+	Hook                 string        `protobuf:"bytes,2,opt,name=hook,proto3" json:"hook,omitempty"`
+	Error                string        `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	Attempts             int32         `protobuf:"varint,4,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *DeadLetter) Reset()         { *m = DeadLetter{} }
+func (m *DeadLetter) String() string { return proto.CompactTextString(m) }
+func (*DeadLetter) ProtoMessage()    {}
+func (*DeadLetter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{46}
+}
+
+func (m *DeadLetter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeadLetter.Unmarshal(m, b)
+}
+func (m *DeadLetter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeadLetter.Marshal(b, m, deterministic)
+}
+func (m *DeadLetter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeadLetter.Merge(m, src)
+}
+func (m *DeadLetter) XXX_Size() int {
+	return xxx_messageInfo_DeadLetter.Size(m)
+}
+func (m *DeadLetter) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeadLetter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeadLetter proto.InternalMessageInfo
+
+func (m *DeadLetter) GetNotification() *Notification {
+	if m != nil {
+		return m.Notification
+	}
+	return nil
+}
+
+func (m *DeadLetter) GetHook() string {
+	if m != nil {
+		return m.Hook
+	}
+	return ""
+}
+
+func (m *DeadLetter) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *DeadLetter) GetAttempts() int32 {
+	if m != nil {
+		return m.Attempts
+	}
+	return 0
+}
+
+type DeadLettersRequest struct {
+	Limit                int32    `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeadLettersRequest) Reset()         { *m = DeadLettersRequest{} }
+func (m *DeadLettersRequest) String() string { return proto.CompactTextString(m) }
+func (*DeadLettersRequest) ProtoMessage()    {}
+func (*DeadLettersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{47}
+}
+
+func (m *DeadLettersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeadLettersRequest.Unmarshal(m, b)
+}
+func (m *DeadLettersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeadLettersRequest.Marshal(b, m, deterministic)
+}
+func (m *DeadLettersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeadLettersRequest.Merge(m, src)
+}
+func (m *DeadLettersRequest) XXX_Size() int {
+	return xxx_messageInfo_DeadLettersRequest.Size(m)
+}
+func (m *DeadLettersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeadLettersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeadLettersRequest proto.InternalMessageInfo
+
+func (m *DeadLettersRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type DeadLettersResponse struct {
+	DeadLetters          []*DeadLetter `protobuf:"bytes,1,rep,name=dead_letters,json=deadLetters,proto3" json:"dead_letters,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *DeadLettersResponse) Reset()         { *m = DeadLettersResponse{} }
+func (m *DeadLettersResponse) String() string { return proto.CompactTextString(m) }
+func (*DeadLettersResponse) ProtoMessage()    {}
+func (*DeadLettersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{48}
+}
+
+func (m *DeadLettersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeadLettersResponse.Unmarshal(m, b)
+}
+func (m *DeadLettersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeadLettersResponse.Marshal(b, m, deterministic)
+}
+func (m *DeadLettersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeadLettersResponse.Merge(m, src)
+}
+func (m *DeadLettersResponse) XXX_Size() int {
+	return xxx_messageInfo_DeadLettersResponse.Size(m)
+}
+func (m *DeadLettersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeadLettersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeadLettersResponse proto.InternalMessageInfo
+
+func (m *DeadLettersResponse) GetDeadLetters() []*DeadLetter {
+	if m != nil {
+		return m.DeadLetters
+	}
+	return nil
+}
+
+type TraceRequest struct {
+	TraceId              string   `protobuf:"bytes,1,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TraceRequest) Reset()         { *m = TraceRequest{} }
+func (m *TraceRequest) String() string { return proto.CompactTextString(m) }
+func (*TraceRequest) ProtoMessage()    {}
+func (*TraceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{49}
+}
+
+func (m *TraceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TraceRequest.Unmarshal(m, b)
+}
+func (m *TraceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TraceRequest.Marshal(b, m, deterministic)
+}
+func (m *TraceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TraceRequest.Merge(m, src)
+}
+func (m *TraceRequest) XXX_Size() int {
+	return xxx_messageInfo_TraceRequest.Size(m)
+}
+func (m *TraceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TraceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TraceRequest proto.InternalMessageInfo
+
+func (m *TraceRequest) GetTraceId() string {
+	if m != nil {
+		return m.TraceId
+	}
+	return ""
+}
+
+type Span struct {
+	Trace                string            `protobuf:"bytes,1,opt,name=trace,proto3" json:"trace,omitempty"`
+	Id                   string            `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Parent               string            `protobuf:"bytes,3,opt,name=parent,proto3" json:"parent,omitempty"`
+	Name                 string            `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Started              int64             `protobuf:"varint,5,opt,name=started,proto3" json:"started,omitempty"`
+	Duration             int64             `protobuf:"varint,6,opt,name=duration,proto3" json:"duration,omitempty"`
+	Node                 string            `protobuf:"bytes,7,opt,name=node,proto3" json:"node,omitempty"`
+	Metadata             map[string]string `protobuf:"bytes,8,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Span) Reset()         { *m = Span{} }
+func (m *Span) String() string { return proto.CompactTextString(m) }
+func (*Span) ProtoMessage()    {}
+func (*Span) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{50}
+}
+
+func (m *Span) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Span.Unmarshal(m, b)
+}
+func (m *Span) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Span.Marshal(b, m, deterministic)
+}
+func (m *Span) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Span.Merge(m, src)
+}
+func (m *Span) XXX_Size() int {
+	return xxx_messageInfo_Span.Size(m)
+}
+func (m *Span) XXX_DiscardUnknown() {
+	xxx_messageInfo_Span.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Span proto.InternalMessageInfo
+
+func (m *Span) GetTrace() string {
+	if m != nil {
+		return m.Trace
+	}
+	return ""
+}
+
+func (m *Span) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Span) GetParent() string {
+	if m != nil {
+		return m.Parent
+	}
+	return ""
+}
+
+func (m *Span) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Span) GetStarted() int64 {
+	if m != nil {
+		return m.Started
+	}
+	return 0
+}
+
+func (m *Span) GetDuration() int64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+func (m *Span) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
+func (m *Span) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+type TraceResponse struct {
+	Spans                []*Span  `protobuf:"bytes,1,rep,name=spans,proto3" json:"spans,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TraceResponse) Reset()         { *m = TraceResponse{} }
+func (m *TraceResponse) String() string { return proto.CompactTextString(m) }
+func (*TraceResponse) ProtoMessage()    {}
+func (*TraceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{51}
+}
+
+func (m *TraceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TraceResponse.Unmarshal(m, b)
+}
+func (m *TraceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TraceResponse.Marshal(b, m, deterministic)
+}
+func (m *TraceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TraceResponse.Merge(m, src)
+}
+func (m *TraceResponse) XXX_Size() int {
+	return xxx_messageInfo_TraceResponse.Size(m)
+}
+func (m *TraceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TraceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TraceResponse proto.InternalMessageInfo
+
+func (m *TraceResponse) GetSpans() []*Span {
+	if m != nil {
+		return m.Spans
+	}
+	return nil
+}
+
+type CreateGroupRequest struct {
+	GroupId              string   `protobuf:"bytes,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	UserId               []string `protobuf:"bytes,2,rep,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateGroupRequest) Reset()         { *m = CreateGroupRequest{} }
+func (m *CreateGroupRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateGroupRequest) ProtoMessage()    {}
+func (*CreateGroupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{52}
+}
+
+func (m *CreateGroupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateGroupRequest.Unmarshal(m, b)
+}
+func (m *CreateGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateGroupRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateGroupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateGroupRequest.Merge(m, src)
+}
+func (m *CreateGroupRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateGroupRequest.Size(m)
+}
+func (m *CreateGroupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateGroupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateGroupRequest proto.InternalMessageInfo
+
+func (m *CreateGroupRequest) GetGroupId() string {
+	if m != nil {
+		return m.GroupId
+	}
+	return ""
+}
+
+func (m *CreateGroupRequest) GetUserId() []string {
+	if m != nil {
+		return m.UserId
+	}
+	return nil
+}
+
+type CreateGroupResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateGroupResponse) Reset()         { *m = CreateGroupResponse{} }
+func (m *CreateGroupResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateGroupResponse) ProtoMessage()    {}
+func (*CreateGroupResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{53}
+}
+
+func (m *CreateGroupResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateGroupResponse.Unmarshal(m, b)
+}
+func (m *CreateGroupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateGroupResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateGroupResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateGroupResponse.Merge(m, src)
+}
+func (m *CreateGroupResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateGroupResponse.Size(m)
+}
+func (m *CreateGroupResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateGroupResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateGroupResponse proto.InternalMessageInfo
+
+type JoinGroupRequest struct {
+	GroupId              string   `protobuf:"bytes,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	UserId               string   `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ConnectRequest) Reset()         { *m = ConnectRequest{} }
-func (m *ConnectRequest) String() string { return proto.CompactTextString(m) }
-func (*ConnectRequest) ProtoMessage()    {}
-func (*ConnectRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_baee4f6301954b8c, []int{5}
+func (m *JoinGroupRequest) Reset()         { *m = JoinGroupRequest{} }
+func (m *JoinGroupRequest) String() string { return proto.CompactTextString(m) }
+func (*JoinGroupRequest) ProtoMessage()    {}
+func (*JoinGroupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{54}
 }
 
-func (m *ConnectRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ConnectRequest.Unmarshal(m, b)
+func (m *JoinGroupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JoinGroupRequest.Unmarshal(m, b)
 }
-func (m *ConnectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ConnectRequest.Marshal(b, m, deterministic)
+func (m *JoinGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JoinGroupRequest.Marshal(b, m, deterministic)
 }
-func (m *ConnectRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ConnectRequest.Merge(m, src)
+func (m *JoinGroupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JoinGroupRequest.Merge(m, src)
 }
-func (m *ConnectRequest) XXX_Size() int {
-	return xxx_messageInfo_ConnectRequest.Size(m)
+func (m *JoinGroupRequest) XXX_Size() int {
+	return xxx_messageInfo_JoinGroupRequest.Size(m)
 }
-func (m *ConnectRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ConnectRequest.DiscardUnknown(m)
+func (m *JoinGroupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_JoinGroupRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ConnectRequest proto.InternalMessageInfo
+var xxx_messageInfo_JoinGroupRequest proto.InternalMessageInfo
 
-func (m *ConnectRequest) GetHeader() *Header {
+func (m *JoinGroupRequest) GetGroupId() string {
 	if m != nil {
-		return m.Header
+		return m.GroupId
 	}
-	return nil
+	return ""
 }
 
-type ConnectResponse struct {
+func (m *JoinGroupRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+type JoinGroupResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ConnectResponse) Reset()         { *m = ConnectResponse{} }
-func (m *ConnectResponse) String() string { return proto.CompactTextString(m) }
-func (*ConnectResponse) ProtoMessage()    {}
-func (*ConnectResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_baee4f6301954b8c, []int{6}
+func (m *JoinGroupResponse) Reset()         { *m = JoinGroupResponse{} }
+func (m *JoinGroupResponse) String() string { return proto.CompactTextString(m) }
+func (*JoinGroupResponse) ProtoMessage()    {}
+func (*JoinGroupResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{55}
 }
 
-func (m *ConnectResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ConnectResponse.Unmarshal(m, b)
+func (m *JoinGroupResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JoinGroupResponse.Unmarshal(m, b)
 }
-func (m *ConnectResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ConnectResponse.Marshal(b, m, deterministic)
+func (m *JoinGroupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JoinGroupResponse.Marshal(b, m, deterministic)
 }
-func (m *ConnectResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ConnectResponse.Merge(m, src)
+func (m *JoinGroupResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JoinGroupResponse.Merge(m, src)
 }
-func (m *ConnectResponse) XXX_Size() int {
-	return xxx_messageInfo_ConnectResponse.Size(m)
+func (m *JoinGroupResponse) XXX_Size() int {
+	return xxx_messageInfo_JoinGroupResponse.Size(m)
 }
-func (m *ConnectResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ConnectResponse.DiscardUnknown(m)
+func (m *JoinGroupResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_JoinGroupResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ConnectResponse proto.InternalMessageInfo
+var xxx_messageInfo_JoinGroupResponse proto.InternalMessageInfo
 
-type DisconnectRequest struct {
-	Header               *Header  `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+type LeaveGroupRequest struct {
+	GroupId              string   `protobuf:"bytes,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	UserId               string   `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DisconnectRequest) Reset()         { *m = DisconnectRequest{} }
-func (m *DisconnectRequest) String() string { return proto.CompactTextString(m) }
-func (*DisconnectRequest) ProtoMessage()    {}
-func (*DisconnectRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_baee4f6301954b8c, []int{7}
+func (m *LeaveGroupRequest) Reset()         { *m = LeaveGroupRequest{} }
+func (m *LeaveGroupRequest) String() string { return proto.CompactTextString(m) }
+func (*LeaveGroupRequest) ProtoMessage()    {}
+func (*LeaveGroupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{56}
 }
 
-func (m *DisconnectRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DisconnectRequest.Unmarshal(m, b)
+func (m *LeaveGroupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeaveGroupRequest.Unmarshal(m, b)
 }
-func (m *DisconnectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DisconnectRequest.Marshal(b, m, deterministic)
+func (m *LeaveGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeaveGroupRequest.Marshal(b, m, deterministic)
 }
-func (m *DisconnectRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DisconnectRequest.Merge(m, src)
+func (m *LeaveGroupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaveGroupRequest.Merge(m, src)
 }
-func (m *DisconnectRequest) XXX_Size() int {
-	return xxx_messageInfo_DisconnectRequest.Size(m)
+func (m *LeaveGroupRequest) XXX_Size() int {
+	return xxx_messageInfo_LeaveGroupRequest.Size(m)
 }
-func (m *DisconnectRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DisconnectRequest.DiscardUnknown(m)
+func (m *LeaveGroupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaveGroupRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DisconnectRequest proto.InternalMessageInfo
+var xxx_messageInfo_LeaveGroupRequest proto.InternalMessageInfo
 
-func (m *DisconnectRequest) GetHeader() *Header {
+func (m *LeaveGroupRequest) GetGroupId() string {
 	if m != nil {
-		return m.Header
+		return m.GroupId
 	}
-	return nil
+	return ""
 }
 
-type DisconnectResponse struct {
+func (m *LeaveGroupRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+type LeaveGroupResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DisconnectResponse) Reset()         { *m = DisconnectResponse{} }
-func (m *DisconnectResponse) String() string { return proto.CompactTextString(m) }
-func (*DisconnectResponse) ProtoMessage()    {}
-func (*DisconnectResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_baee4f6301954b8c, []int{8}
+func (m *LeaveGroupResponse) Reset()         { *m = LeaveGroupResponse{} }
+func (m *LeaveGroupResponse) String() string { return proto.CompactTextString(m) }
+func (*LeaveGroupResponse) ProtoMessage()    {}
+func (*LeaveGroupResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{57}
 }
 
-func (m *DisconnectResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DisconnectResponse.Unmarshal(m, b)
+func (m *LeaveGroupResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeaveGroupResponse.Unmarshal(m, b)
 }
-func (m *DisconnectResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DisconnectResponse.Marshal(b, m, deterministic)
+func (m *LeaveGroupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeaveGroupResponse.Marshal(b, m, deterministic)
 }
-func (m *DisconnectResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DisconnectResponse.Merge(m, src)
+func (m *LeaveGroupResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaveGroupResponse.Merge(m, src)
 }
-func (m *DisconnectResponse) XXX_Size() int {
-	return xxx_messageInfo_DisconnectResponse.Size(m)
+func (m *LeaveGroupResponse) XXX_Size() int {
+	return xxx_messageInfo_LeaveGroupResponse.Size(m)
 }
-func (m *DisconnectResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_DisconnectResponse.DiscardUnknown(m)
+func (m *LeaveGroupResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaveGroupResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DisconnectResponse proto.InternalMessageInfo
+var xxx_messageInfo_LeaveGroupResponse proto.InternalMessageInfo
 
-type UnicastRequest struct {
-	UserId               string    `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Event                *Event    `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`
-	UserSelector         *Selector `protobuf:"bytes,3,opt,name=user_selector,json=userSelector,proto3" json:"user_selector,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+type MembersRequest struct {
+	GroupId              string   `protobuf:"bytes,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *UnicastRequest) Reset()         { *m = UnicastRequest{} }
-func (m *UnicastRequest) String() string { return proto.CompactTextString(m) }
-func (*UnicastRequest) ProtoMessage()    {}
-func (*UnicastRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_baee4f6301954b8c, []int{9}
+func (m *MembersRequest) Reset()         { *m = MembersRequest{} }
+func (m *MembersRequest) String() string { return proto.CompactTextString(m) }
+func (*MembersRequest) ProtoMessage()    {}
+func (*MembersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{58}
 }
 
-func (m *UnicastRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_UnicastRequest.Unmarshal(m, b)
+func (m *MembersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MembersRequest.Unmarshal(m, b)
 }
-func (m *UnicastRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UnicastRequest.Marshal(b, m, deterministic)
+func (m *MembersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MembersRequest.Marshal(b, m, deterministic)
 }
-func (m *UnicastRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UnicastRequest.Merge(m, src)
+func (m *MembersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MembersRequest.Merge(m, src)
 }
-func (m *UnicastRequest) XXX_Size() int {
-	return xxx_messageInfo_UnicastRequest.Size(m)
+func (m *MembersRequest) XXX_Size() int {
+	return xxx_messageInfo_MembersRequest.Size(m)
 }
-func (m *UnicastRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_UnicastRequest.DiscardUnknown(m)
+func (m *MembersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MembersRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UnicastRequest proto.InternalMessageInfo
+var xxx_messageInfo_MembersRequest proto.InternalMessageInfo
 
-func (m *UnicastRequest) GetUserId() string {
+func (m *MembersRequest) GetGroupId() string {
 	if m != nil {
-		return m.UserId
+		return m.GroupId
 	}
 	return ""
 }
 
-func (m *UnicastRequest) GetEvent() *Event {
-	if m != nil {
-		return m.Event
-	}
-	return nil
-}
-
-func (m *UnicastRequest) GetUserSelector() *Selector {
-	if m != nil {
-		return m.UserSelector
-	}
-	return nil
-}
-
-type UnicastResponse struct {
+type MembersResponse struct {
+	UserId               []string `protobuf:"bytes,1,rep,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *UnicastResponse) Reset()         { *m = UnicastResponse{} }
-func (m *UnicastResponse) String() string { return proto.CompactTextString(m) }
-func (*UnicastResponse) ProtoMessage()    {}
-func (*UnicastResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_baee4f6301954b8c, []int{10}
+func (m *MembersResponse) Reset()         { *m = MembersResponse{} }
+func (m *MembersResponse) String() string { return proto.CompactTextString(m) }
+func (*MembersResponse) ProtoMessage()    {}
+func (*MembersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{59}
 }
 
-func (m *UnicastResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_UnicastResponse.Unmarshal(m, b)
+func (m *MembersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MembersResponse.Unmarshal(m, b)
 }
-func (m *UnicastResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UnicastResponse.Marshal(b, m, deterministic)
+func (m *MembersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MembersResponse.Marshal(b, m, deterministic)
 }
-func (m *UnicastResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UnicastResponse.Merge(m, src)
+func (m *MembersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MembersResponse.Merge(m, src)
 }
-func (m *UnicastResponse) XXX_Size() int {
-	return xxx_messageInfo_UnicastResponse.Size(m)
+func (m *MembersResponse) XXX_Size() int {
+	return xxx_messageInfo_MembersResponse.Size(m)
 }
-func (m *UnicastResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_UnicastResponse.DiscardUnknown(m)
+func (m *MembersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MembersResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UnicastResponse proto.InternalMessageInfo
+var xxx_messageInfo_MembersResponse proto.InternalMessageInfo
 
-type MulticastRequest struct {
-	UserId               []string             `protobuf:"bytes,1,rep,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+func (m *MembersResponse) GetUserId() []string {
+	if m != nil {
+		return m.UserId
+	}
+	return nil
+}
+
+type BroadcastRequest struct {
+	GroupId              string               `protobuf:"bytes,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
 	Event                *Event               `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`
 	UserSelector         map[string]*Selector `protobuf:"bytes,3,rep,name=user_selector,json=userSelector,proto3" json:"user_selector,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
@@ -551,85 +3366,85 @@ type MulticastRequest struct {
 	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *MulticastRequest) Reset()         { *m = MulticastRequest{} }
-func (m *MulticastRequest) String() string { return proto.CompactTextString(m) }
-func (*MulticastRequest) ProtoMessage()    {}
-func (*MulticastRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_baee4f6301954b8c, []int{11}
+func (m *BroadcastRequest) Reset()         { *m = BroadcastRequest{} }
+func (m *BroadcastRequest) String() string { return proto.CompactTextString(m) }
+func (*BroadcastRequest) ProtoMessage()    {}
+func (*BroadcastRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{60}
 }
 
-func (m *MulticastRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_MulticastRequest.Unmarshal(m, b)
+func (m *BroadcastRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BroadcastRequest.Unmarshal(m, b)
 }
-func (m *MulticastRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_MulticastRequest.Marshal(b, m, deterministic)
+func (m *BroadcastRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BroadcastRequest.Marshal(b, m, deterministic)
 }
-func (m *MulticastRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MulticastRequest.Merge(m, src)
+func (m *BroadcastRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BroadcastRequest.Merge(m, src)
 }
-func (m *MulticastRequest) XXX_Size() int {
-	return xxx_messageInfo_MulticastRequest.Size(m)
+func (m *BroadcastRequest) XXX_Size() int {
+	return xxx_messageInfo_BroadcastRequest.Size(m)
 }
-func (m *MulticastRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_MulticastRequest.DiscardUnknown(m)
+func (m *BroadcastRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BroadcastRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MulticastRequest proto.InternalMessageInfo
+var xxx_messageInfo_BroadcastRequest proto.InternalMessageInfo
 
-func (m *MulticastRequest) GetUserId() []string {
+func (m *BroadcastRequest) GetGroupId() string {
 	if m != nil {
-		return m.UserId
+		return m.GroupId
 	}
-	return nil
+	return ""
 }
 
-func (m *MulticastRequest) GetEvent() *Event {
+func (m *BroadcastRequest) GetEvent() *Event {
 	if m != nil {
 		return m.Event
 	}
 	return nil
 }
 
-func (m *MulticastRequest) GetUserSelector() map[string]*Selector {
+func (m *BroadcastRequest) GetUserSelector() map[string]*Selector {
 	if m != nil {
 		return m.UserSelector
 	}
 	return nil
 }
 
-type MulticastResponse struct {
+type BroadcastResponse struct {
 	UserErrcode          map[string]ErrorCode `protobuf:"bytes,1,rep,name=user_errcode,json=userErrcode,proto3" json:"user_errcode,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3,enum=sims.proto.ErrorCode"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *MulticastResponse) Reset()         { *m = MulticastResponse{} }
-func (m *MulticastResponse) String() string { return proto.CompactTextString(m) }
-func (*MulticastResponse) ProtoMessage()    {}
-func (*MulticastResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_baee4f6301954b8c, []int{12}
+func (m *BroadcastResponse) Reset()         { *m = BroadcastResponse{} }
+func (m *BroadcastResponse) String() string { return proto.CompactTextString(m) }
+func (*BroadcastResponse) ProtoMessage()    {}
+func (*BroadcastResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{61}
 }
 
-func (m *MulticastResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_MulticastResponse.Unmarshal(m, b)
+func (m *BroadcastResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BroadcastResponse.Unmarshal(m, b)
 }
-func (m *MulticastResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_MulticastResponse.Marshal(b, m, deterministic)
+func (m *BroadcastResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BroadcastResponse.Marshal(b, m, deterministic)
 }
-func (m *MulticastResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MulticastResponse.Merge(m, src)
+func (m *BroadcastResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BroadcastResponse.Merge(m, src)
 }
-func (m *MulticastResponse) XXX_Size() int {
-	return xxx_messageInfo_MulticastResponse.Size(m)
+func (m *BroadcastResponse) XXX_Size() int {
+	return xxx_messageInfo_BroadcastResponse.Size(m)
 }
-func (m *MulticastResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_MulticastResponse.DiscardUnknown(m)
+func (m *BroadcastResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BroadcastResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MulticastResponse proto.InternalMessageInfo
+var xxx_messageInfo_BroadcastResponse proto.InternalMessageInfo
 
-func (m *MulticastResponse) GetUserErrcode() map[string]ErrorCode {
+func (m *BroadcastResponse) GetUserErrcode() map[string]ErrorCode {
 	if m != nil {
 		return m.UserErrcode
 	}
@@ -647,7 +3462,7 @@ func (m *HeartbeatRequest) Reset()         { *m = HeartbeatRequest{} }
 func (m *HeartbeatRequest) String() string { return proto.CompactTextString(m) }
 func (*HeartbeatRequest) ProtoMessage()    {}
 func (*HeartbeatRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_baee4f6301954b8c, []int{13}
+	return fileDescriptor_baee4f6301954b8c, []int{62}
 }
 
 func (m *HeartbeatRequest) XXX_Unmarshal(b []byte) error {
@@ -685,7 +3500,7 @@ func (m *HeartbeatResponse) Reset()         { *m = HeartbeatResponse{} }
 func (m *HeartbeatResponse) String() string { return proto.CompactTextString(m) }
 func (*HeartbeatResponse) ProtoMessage()    {}
 func (*HeartbeatResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_baee4f6301954b8c, []int{14}
+	return fileDescriptor_baee4f6301954b8c, []int{63}
 }
 
 func (m *HeartbeatResponse) XXX_Unmarshal(b []byte) error {
@@ -706,6 +3521,84 @@ func (m *HeartbeatResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_HeartbeatResponse proto.InternalMessageInfo
 
+type AckRequest struct {
+	Header               *Header  `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Seq                  uint64   `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AckRequest) Reset()         { *m = AckRequest{} }
+func (m *AckRequest) String() string { return proto.CompactTextString(m) }
+func (*AckRequest) ProtoMessage()    {}
+func (*AckRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{64}
+}
+
+func (m *AckRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AckRequest.Unmarshal(m, b)
+}
+func (m *AckRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AckRequest.Marshal(b, m, deterministic)
+}
+func (m *AckRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AckRequest.Merge(m, src)
+}
+func (m *AckRequest) XXX_Size() int {
+	return xxx_messageInfo_AckRequest.Size(m)
+}
+func (m *AckRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AckRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AckRequest proto.InternalMessageInfo
+
+func (m *AckRequest) GetHeader() *Header {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *AckRequest) GetSeq() uint64 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+type AckResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AckResponse) Reset()         { *m = AckResponse{} }
+func (m *AckResponse) String() string { return proto.CompactTextString(m) }
+func (*AckResponse) ProtoMessage()    {}
+func (*AckResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_baee4f6301954b8c, []int{65}
+}
+
+func (m *AckResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AckResponse.Unmarshal(m, b)
+}
+func (m *AckResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AckResponse.Marshal(b, m, deterministic)
+}
+func (m *AckResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AckResponse.Merge(m, src)
+}
+func (m *AckResponse) XXX_Size() int {
+	return xxx_messageInfo_AckResponse.Size(m)
+}
+func (m *AckResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AckResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AckResponse proto.InternalMessageInfo
+
 type ListRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -716,7 +3609,7 @@ func (m *ListRequest) Reset()         { *m = ListRequest{} }
 func (m *ListRequest) String() string { return proto.CompactTextString(m) }
 func (*ListRequest) ProtoMessage()    {}
 func (*ListRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_baee4f6301954b8c, []int{15}
+	return fileDescriptor_baee4f6301954b8c, []int{66}
 }
 
 func (m *ListRequest) XXX_Unmarshal(b []byte) error {
@@ -743,6 +3636,7 @@ type Channel struct {
 	Birth                string   `protobuf:"bytes,3,opt,name=birth,proto3" json:"birth,omitempty"`
 	LastHeartbeat        string   `protobuf:"bytes,4,opt,name=last_heartbeat,json=lastHeartbeat,proto3" json:"last_heartbeat,omitempty"`
 	Active               int32    `protobuf:"varint,5,opt,name=active,proto3" json:"active,omitempty"`
+	UserAgent            string   `protobuf:"bytes,6,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -752,7 +3646,7 @@ func (m *Channel) Reset()         { *m = Channel{} }
 func (m *Channel) String() string { return proto.CompactTextString(m) }
 func (*Channel) ProtoMessage()    {}
 func (*Channel) Descriptor() ([]byte, []int) {
-	return fileDescriptor_baee4f6301954b8c, []int{16}
+	return fileDescriptor_baee4f6301954b8c, []int{67}
 }
 
 func (m *Channel) XXX_Unmarshal(b []byte) error {
@@ -808,6 +3702,13 @@ func (m *Channel) GetActive() int32 {
 	return 0
 }
 
+func (m *Channel) GetUserAgent() string {
+	if m != nil {
+		return m.UserAgent
+	}
+	return ""
+}
+
 type ListResponse struct {
 	Channels             []*Channel `protobuf:"bytes,1,rep,name=channels,proto3" json:"channels,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
@@ -819,7 +3720,7 @@ func (m *ListResponse) Reset()         { *m = ListResponse{} }
 func (m *ListResponse) String() string { return proto.CompactTextString(m) }
 func (*ListResponse) ProtoMessage()    {}
 func (*ListResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_baee4f6301954b8c, []int{17}
+	return fileDescriptor_baee4f6301954b8c, []int{68}
 }
 
 func (m *ListResponse) XXX_Unmarshal(b []byte) error {
@@ -849,24 +3750,83 @@ func (m *ListResponse) GetChannels() []*Channel {
 
 func init() {
 	proto.RegisterEnum("sims.proto.ErrorCode", ErrorCode_name, ErrorCode_value)
+	proto.RegisterEnum("sims.proto.PresenceStatus", PresenceStatus_name, PresenceStatus_value)
 	proto.RegisterEnum("sims.proto.EventType", EventType_name, EventType_value)
+	proto.RegisterEnum("sims.proto.Priority", Priority_name, Priority_value)
+	proto.RegisterEnum("sims.proto.HookEvent", HookEvent_name, HookEvent_value)
+	proto.RegisterEnum("sims.proto.ReceiptStatus", ReceiptStatus_name, ReceiptStatus_value)
 	proto.RegisterType((*ServerConfig)(nil), "sims.proto.ServerConfig")
 	proto.RegisterType((*Header)(nil), "sims.proto.Header")
 	proto.RegisterType((*Event)(nil), "sims.proto.Event")
+	proto.RegisterType((*Reconnect)(nil), "sims.proto.Reconnect")
+	proto.RegisterType((*Kicked)(nil), "sims.proto.Kicked")
 	proto.RegisterType((*Selector)(nil), "sims.proto.Selector")
 	proto.RegisterType((*EventsRequest)(nil), "sims.proto.EventsRequest")
+	proto.RegisterType((*PollRequest)(nil), "sims.proto.PollRequest")
+	proto.RegisterType((*PollResponse)(nil), "sims.proto.PollResponse")
 	proto.RegisterType((*ConnectRequest)(nil), "sims.proto.ConnectRequest")
 	proto.RegisterType((*ConnectResponse)(nil), "sims.proto.ConnectResponse")
+	proto.RegisterType((*SessionConnect)(nil), "sims.proto.SessionConnect")
+	proto.RegisterType((*SessionRequest)(nil), "sims.proto.SessionRequest")
+	proto.RegisterType((*SessionResult)(nil), "sims.proto.SessionResult")
+	proto.RegisterType((*SessionResponse)(nil), "sims.proto.SessionResponse")
 	proto.RegisterType((*DisconnectRequest)(nil), "sims.proto.DisconnectRequest")
 	proto.RegisterType((*DisconnectResponse)(nil), "sims.proto.DisconnectResponse")
 	proto.RegisterType((*UnicastRequest)(nil), "sims.proto.UnicastRequest")
 	proto.RegisterType((*UnicastResponse)(nil), "sims.proto.UnicastResponse")
+	proto.RegisterType((*Receipt)(nil), "sims.proto.Receipt")
+	proto.RegisterType((*ReceiptsRequest)(nil), "sims.proto.ReceiptsRequest")
 	proto.RegisterType((*MulticastRequest)(nil), "sims.proto.MulticastRequest")
 	proto.RegisterMapType((map[string]*Selector)(nil), "sims.proto.MulticastRequest.UserSelectorEntry")
 	proto.RegisterType((*MulticastResponse)(nil), "sims.proto.MulticastResponse")
 	proto.RegisterMapType((map[string]ErrorCode)(nil), "sims.proto.MulticastResponse.UserErrcodeEntry")
+	proto.RegisterType((*CancelRequest)(nil), "sims.proto.CancelRequest")
+	proto.RegisterType((*CancelResponse)(nil), "sims.proto.CancelResponse")
+	proto.RegisterType((*Schedule)(nil), "sims.proto.Schedule")
+	proto.RegisterType((*PublishRequest)(nil), "sims.proto.PublishRequest")
+	proto.RegisterType((*PublishResponse)(nil), "sims.proto.PublishResponse")
+	proto.RegisterType((*SubscribeRequest)(nil), "sims.proto.SubscribeRequest")
+	proto.RegisterType((*SubscribeResponse)(nil), "sims.proto.SubscribeResponse")
+	proto.RegisterType((*UnsubscribeRequest)(nil), "sims.proto.UnsubscribeRequest")
+	proto.RegisterType((*UnsubscribeResponse)(nil), "sims.proto.UnsubscribeResponse")
+	proto.RegisterType((*UserPresence)(nil), "sims.proto.UserPresence")
+	proto.RegisterType((*GetPresenceRequest)(nil), "sims.proto.GetPresenceRequest")
+	proto.RegisterType((*GetPresenceResponse)(nil), "sims.proto.GetPresenceResponse")
+	proto.RegisterMapType((map[string]*UserPresence)(nil), "sims.proto.GetPresenceResponse.PresenceEntry")
+	proto.RegisterType((*WatchPresenceRequest)(nil), "sims.proto.WatchPresenceRequest")
+	proto.RegisterType((*FetchRequest)(nil), "sims.proto.FetchRequest")
+	proto.RegisterType((*FetchResponse)(nil), "sims.proto.FetchResponse")
+	proto.RegisterType((*KickRequest)(nil), "sims.proto.KickRequest")
+	proto.RegisterType((*KickResponse)(nil), "sims.proto.KickResponse")
+	proto.RegisterType((*BanRequest)(nil), "sims.proto.BanRequest")
+	proto.RegisterType((*BanResponse)(nil), "sims.proto.BanResponse")
+	proto.RegisterType((*Ban)(nil), "sims.proto.Ban")
+	proto.RegisterType((*ListBansRequest)(nil), "sims.proto.ListBansRequest")
+	proto.RegisterType((*ListBansResponse)(nil), "sims.proto.ListBansResponse")
+	proto.RegisterType((*Notification)(nil), "sims.proto.Notification")
+	proto.RegisterType((*DeadLetter)(nil), "sims.proto.DeadLetter")
+	proto.RegisterType((*DeadLettersRequest)(nil), "sims.proto.DeadLettersRequest")
+	proto.RegisterType((*DeadLettersResponse)(nil), "sims.proto.DeadLettersResponse")
+	proto.RegisterType((*TraceRequest)(nil), "sims.proto.TraceRequest")
+	proto.RegisterType((*Span)(nil), "sims.proto.Span")
+	proto.RegisterMapType((map[string]string)(nil), "sims.proto.Span.MetadataEntry")
+	proto.RegisterType((*TraceResponse)(nil), "sims.proto.TraceResponse")
+	proto.RegisterType((*CreateGroupRequest)(nil), "sims.proto.CreateGroupRequest")
+	proto.RegisterType((*CreateGroupResponse)(nil), "sims.proto.CreateGroupResponse")
+	proto.RegisterType((*JoinGroupRequest)(nil), "sims.proto.JoinGroupRequest")
+	proto.RegisterType((*JoinGroupResponse)(nil), "sims.proto.JoinGroupResponse")
+	proto.RegisterType((*LeaveGroupRequest)(nil), "sims.proto.LeaveGroupRequest")
+	proto.RegisterType((*LeaveGroupResponse)(nil), "sims.proto.LeaveGroupResponse")
+	proto.RegisterType((*MembersRequest)(nil), "sims.proto.MembersRequest")
+	proto.RegisterType((*MembersResponse)(nil), "sims.proto.MembersResponse")
+	proto.RegisterType((*BroadcastRequest)(nil), "sims.proto.BroadcastRequest")
+	proto.RegisterMapType((map[string]*Selector)(nil), "sims.proto.BroadcastRequest.UserSelectorEntry")
+	proto.RegisterType((*BroadcastResponse)(nil), "sims.proto.BroadcastResponse")
+	proto.RegisterMapType((map[string]ErrorCode)(nil), "sims.proto.BroadcastResponse.UserErrcodeEntry")
 	proto.RegisterType((*HeartbeatRequest)(nil), "sims.proto.HeartbeatRequest")
 	proto.RegisterType((*HeartbeatResponse)(nil), "sims.proto.HeartbeatResponse")
+	proto.RegisterType((*AckRequest)(nil), "sims.proto.AckRequest")
+	proto.RegisterType((*AckResponse)(nil), "sims.proto.AckResponse")
 	proto.RegisterType((*ListRequest)(nil), "sims.proto.ListRequest")
 	proto.RegisterType((*Channel)(nil), "sims.proto.Channel")
 	proto.RegisterType((*ListResponse)(nil), "sims.proto.ListResponse")
@@ -875,64 +3835,207 @@ func init() {
 func init() { proto.RegisterFile("sims.proto", fileDescriptor_baee4f6301954b8c) }
 
 var fileDescriptor_baee4f6301954b8c = []byte{
-	// 933 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0x5b, 0x6f, 0xe3, 0x44,
-	0x14, 0x5e, 0xe7, 0xd6, 0xe4, 0xe4, 0xb2, 0x93, 0x69, 0xb7, 0x5b, 0xb2, 0x17, 0xad, 0x22, 0x21,
-	0xba, 0x45, 0x4a, 0x51, 0x78, 0xd9, 0x05, 0xc4, 0x2a, 0x17, 0x97, 0x18, 0x5a, 0xa7, 0x8c, 0x9d,
-	0x6a, 0x8b, 0x90, 0x22, 0xc7, 0x1e, 0x1a, 0x8b, 0xd4, 0x0e, 0x63, 0x27, 0x52, 0x25, 0x1e, 0xf8,
-	0x03, 0xbc, 0xf1, 0x82, 0xf8, 0x2d, 0x3c, 0xf1, 0xc7, 0xd0, 0x8c, 0x27, 0xae, 0xed, 0x34, 0x2f,
-	0x7d, 0x8a, 0xe7, 0x5c, 0xbf, 0xf9, 0xce, 0xc9, 0x37, 0x00, 0x81, 0x7b, 0x1b, 0x74, 0x96, 0xcc,
-	0x0f, 0x7d, 0x9c, 0xf8, 0x6e, 0x37, 0xa0, 0x66, 0x50, 0xb6, 0xa6, 0x6c, 0xe0, 0x7b, 0xbf, 0xb8,
-	0x37, 0xed, 0xdf, 0xa1, 0x34, 0xa2, 0x96, 0x43, 0x19, 0x7e, 0x05, 0xc0, 0xe8, 0x6f, 0x2b, 0x1a,
-	0x84, 0x53, 0xd7, 0x39, 0x52, 0xde, 0x28, 0xc7, 0x15, 0x52, 0x91, 0x16, 0xcd, 0xc1, 0xcf, 0x61,
-	0x6f, 0x15, 0x50, 0xc6, 0x7d, 0x39, 0xe1, 0x2b, 0xf1, 0xa3, 0xe6, 0xe0, 0x17, 0x50, 0x71, 0xe8,
-	0xda, 0xb5, 0x29, 0x77, 0xe5, 0x85, 0xab, 0x1c, 0x19, 0x34, 0x87, 0x17, 0x15, 0x59, 0xd6, 0x0d,
-	0xf5, 0xc2, 0xa3, 0x42, 0x54, 0x94, 0x5b, 0x7a, 0xdc, 0xd0, 0x3e, 0x83, 0xa2, 0xba, 0xa6, 0x5e,
-	0x88, 0xdf, 0x42, 0x21, 0xbc, 0x5b, 0x52, 0xd1, 0xb6, 0xd1, 0x7d, 0xd6, 0xb9, 0x47, 0xdc, 0x11,
-	0x01, 0xe6, 0xdd, 0x92, 0x12, 0x11, 0x82, 0x31, 0x14, 0x1c, 0x2b, 0xb4, 0x04, 0x8a, 0x1a, 0x11,
-	0xdf, 0xed, 0xb7, 0x50, 0x36, 0xe8, 0x82, 0xda, 0xa1, 0xcf, 0x32, 0x2d, 0x95, 0x6c, 0xcb, 0xaf,
-	0xa1, 0x2e, 0x2a, 0x06, 0x24, 0xba, 0x1a, 0x3e, 0x81, 0xd2, 0x5c, 0x30, 0x20, 0x62, 0xab, 0x5d,
-	0x9c, 0x6c, 0x1e, 0x71, 0x43, 0x64, 0x44, 0xfb, 0x1b, 0x68, 0x0c, 0x7c, 0xcf, 0xa3, 0x76, 0xf8,
-	0x98, 0xec, 0x26, 0x3c, 0x8d, 0xb3, 0x83, 0xa5, 0xef, 0x05, 0xb4, 0xfd, 0x01, 0x9a, 0x43, 0x37,
-	0xb0, 0x1f, 0x5f, 0xf3, 0x00, 0x70, 0xb2, 0x80, 0x2c, 0xfb, 0xa7, 0x02, 0x8d, 0x89, 0xe7, 0xda,
-	0x56, 0x10, 0x17, 0x4d, 0xcc, 0x4f, 0x49, 0xcd, 0xef, 0x33, 0x28, 0x52, 0x4e, 0x88, 0x20, 0xb4,
-	0xda, 0x6d, 0x6e, 0x71, 0x4f, 0x22, 0x3f, 0x7e, 0x0f, 0x75, 0x51, 0x21, 0x90, 0x4c, 0x8b, 0x61,
-	0x57, 0xbb, 0x07, 0xc9, 0x84, 0xcd, 0x14, 0x48, 0x8d, 0x87, 0x6e, 0x4e, 0xfc, 0xe6, 0x31, 0x1c,
-	0x09, 0xf1, 0x8f, 0x1c, 0xa0, 0x8b, 0xd5, 0x22, 0xdc, 0x0d, 0x32, 0xff, 0x18, 0x90, 0xc6, 0x36,
-	0xc8, 0xfc, 0x71, 0xb5, 0xdb, 0x49, 0x26, 0x64, 0xdb, 0x76, 0x26, 0x09, 0xac, 0xaa, 0x17, 0xb2,
-	0xbb, 0x34, 0xfc, 0xd6, 0x04, 0x9a, 0x5b, 0x21, 0x18, 0x41, 0xfe, 0x57, 0x7a, 0x27, 0xc9, 0xe4,
-	0x9f, 0xf8, 0x04, 0x8a, 0x6b, 0x6b, 0xb1, 0xa2, 0x12, 0xe4, 0xc3, 0xc4, 0x44, 0x21, 0x5f, 0xe5,
-	0xde, 0x29, 0xed, 0x7f, 0x15, 0x68, 0x26, 0xb0, 0x44, 0xc4, 0xe0, 0x1f, 0x41, 0x34, 0x9f, 0x52,
-	0xc6, 0x6c, 0xdf, 0xa1, 0x82, 0x88, 0xdd, 0x17, 0x88, 0x92, 0xc4, 0x0d, 0xd4, 0x28, 0x21, 0xba,
-	0x40, 0x75, 0x75, 0x6f, 0x69, 0x4d, 0x00, 0x65, 0x03, 0x1e, 0x80, 0xff, 0x79, 0x12, 0x7e, 0xf6,
-	0x4f, 0xc8, 0x98, 0xcf, 0x06, 0xbe, 0x43, 0x93, 0xf8, 0xbf, 0x05, 0x34, 0xa2, 0x16, 0x0b, 0x67,
-	0xd4, 0x7a, 0xd4, 0xee, 0xee, 0x43, 0x33, 0x91, 0x2f, 0xf7, 0xa2, 0x0e, 0xd5, 0x73, 0x37, 0x1e,
-	0x4d, 0xfb, 0x2f, 0x05, 0xf6, 0x06, 0x73, 0xcb, 0xf3, 0xe8, 0x62, 0xf7, 0x0a, 0xa7, 0x24, 0x28,
-	0x97, 0x91, 0xa0, 0x03, 0x28, 0xce, 0x5c, 0x16, 0xce, 0xa5, 0x36, 0x45, 0x07, 0xfc, 0x29, 0x34,
-	0x16, 0x56, 0x10, 0x4e, 0xe7, 0x1b, 0x00, 0x52, 0x9c, 0xea, 0xdc, 0x1a, 0xa3, 0xc2, 0x87, 0x50,
-	0xb2, 0xec, 0xd0, 0x5d, 0xd3, 0xa3, 0xe2, 0x1b, 0xe5, 0xb8, 0x48, 0xe4, 0xa9, 0xfd, 0x01, 0x6a,
-	0x11, 0x4a, 0x39, 0xb4, 0x53, 0x28, 0xdb, 0x11, 0xca, 0x40, 0x0e, 0x6c, 0x3f, 0x79, 0x71, 0x79,
-	0x03, 0x12, 0x07, 0x9d, 0xfc, 0xa7, 0x40, 0x25, 0x26, 0x15, 0xef, 0xc3, 0x53, 0x95, 0x90, 0xe9,
-	0x44, 0x37, 0x2e, 0xd5, 0x81, 0x76, 0xa6, 0xa9, 0x43, 0xf4, 0x04, 0x37, 0xa1, 0xce, 0x8d, 0xfa,
-	0xd8, 0x9c, 0x9e, 0x8d, 0x27, 0xfa, 0x10, 0x29, 0xf8, 0x10, 0x30, 0x37, 0xf5, 0xce, 0x89, 0xda,
-	0x1b, 0x5e, 0x4f, 0xd5, 0x8f, 0x9a, 0x61, 0x1a, 0x28, 0xb7, 0xb1, 0x5f, 0x68, 0x86, 0xa1, 0xe9,
-	0xdf, 0x4d, 0x27, 0x86, 0x4a, 0xb4, 0x21, 0xca, 0x67, 0xed, 0x23, 0xb5, 0x37, 0x54, 0x09, 0x2a,
-	0x6c, 0xfa, 0xe9, 0xe3, 0xe9, 0x60, 0xac, 0x1b, 0x93, 0x0b, 0x95, 0xa0, 0x22, 0x7e, 0x06, 0xcd,
-	0x64, 0xb0, 0x7a, 0xa5, 0xea, 0x26, 0x2a, 0xe1, 0x16, 0x1c, 0x72, 0xb3, 0xa6, 0x5f, 0xf5, 0xce,
-	0xb5, 0x61, 0x64, 0x9e, 0x9a, 0xd7, 0x97, 0x2a, 0xda, 0x3b, 0xf9, 0x19, 0x2a, 0xb1, 0x3c, 0x0b,
-	0xbc, 0x57, 0x26, 0x6f, 0x42, 0xcc, 0xbe, 0xda, 0x33, 0xd1, 0x13, 0x5c, 0x83, 0x32, 0x37, 0x99,
-	0xea, 0x47, 0x13, 0x29, 0x9b, 0xd3, 0xf7, 0xc6, 0x58, 0x47, 0x39, 0x8c, 0xa0, 0xc6, 0x4f, 0x97,
-	0x64, 0x6c, 0x8e, 0xfb, 0x93, 0x33, 0x94, 0xc7, 0x0d, 0x00, 0x6e, 0xe9, 0x6b, 0x7a, 0x8f, 0x5c,
-	0xa3, 0x42, 0xf7, 0x9f, 0x1c, 0xe4, 0x47, 0xab, 0x19, 0xee, 0xc3, 0x9e, 0xd4, 0x4d, 0xdc, 0x4a,
-	0xb1, 0x9a, 0x92, 0xcd, 0xd6, 0x8b, 0x07, 0x7d, 0x72, 0x40, 0x23, 0xa8, 0xdc, 0x4f, 0xf5, 0x65,
-	0x66, 0x29, 0x53, 0x2b, 0xdc, 0x7a, 0xb5, 0xc3, 0x2b, 0x2b, 0xfd, 0x00, 0x70, 0xaf, 0xb8, 0x38,
-	0x15, 0xbc, 0x25, 0xe5, 0xad, 0xd7, 0xbb, 0xdc, 0xb2, 0xd8, 0x7b, 0x28, 0xf0, 0x3d, 0xc2, 0xcf,
-	0x93, 0x71, 0x89, 0xfd, 0x6f, 0x1d, 0x6d, 0x3b, 0xa2, 0xd4, 0xee, 0x10, 0xca, 0x46, 0xc8, 0xa8,
-	0x75, 0x4b, 0x19, 0x7e, 0x07, 0xa5, 0xe8, 0x51, 0xc3, 0x9f, 0x6c, 0x29, 0xe3, 0xe6, 0xa1, 0x6b,
-	0x6d, 0x8b, 0xe6, 0x17, 0x4a, 0xf7, 0x6f, 0x05, 0x2a, 0x97, 0xab, 0xd9, 0xc2, 0x0d, 0xe6, 0x94,
-	0x71, 0xa6, 0xa5, 0x4e, 0xa7, 0x99, 0x4e, 0xbf, 0x25, 0x69, 0xa6, 0x33, 0xc2, 0xce, 0x99, 0x8e,
-	0xf5, 0x29, 0xcd, 0x74, 0x56, 0x77, 0xd3, 0x4c, 0x6f, 0x89, 0x5a, 0xff, 0xf5, 0x4f, 0x2f, 0x6f,
-	0xdc, 0x70, 0xbe, 0x9a, 0x75, 0x6c, 0xff, 0xf6, 0xd4, 0xb2, 0x17, 0x6e, 0xb0, 0x3c, 0xe5, 0x19,
-	0xa7, 0x22, 0x63, 0x56, 0x12, 0x3f, 0x5f, 0xfe, 0x1f, 0x00, 0x00, 0xff, 0xff, 0x47, 0xbd, 0x69,
-	0x2f, 0xec, 0x08, 0x00, 0x00,
+	// 3230 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x39, 0x3b, 0x70, 0xe3, 0xd6,
+	0xb5, 0x0b, 0xfe, 0x79, 0xf8, 0x11, 0x74, 0x25, 0xad, 0xb9, 0xd8, 0xef, 0xc3, 0x9b, 0xf7, 0xec,
+	0x95, 0xc7, 0xda, 0x7d, 0xf2, 0x1b, 0x7f, 0x77, 0xbc, 0x43, 0x91, 0x90, 0x08, 0xaf, 0xf8, 0x31,
+	0x48, 0x2a, 0xde, 0x4c, 0x66, 0x18, 0x88, 0xbc, 0xbb, 0xc2, 0x88, 0x04, 0x68, 0x00, 0x54, 0xac,
+	0x99, 0x4c, 0x32, 0x93, 0x2e, 0x55, 0xd2, 0xa4, 0x4a, 0x91, 0x32, 0x93, 0x22, 0x55, 0x8a, 0x54,
+	0x99, 0x49, 0xe7, 0x49, 0x95, 0x2a, 0xad, 0xcb, 0x74, 0x6e, 0x53, 0x67, 0xee, 0x07, 0xc0, 0x05,
+	0x48, 0x4a, 0xde, 0x8d, 0x5d, 0x91, 0xf7, 0x9c, 0x73, 0xcf, 0x3d, 0xbf, 0x7b, 0xee, 0x39, 0x07,
+	0x00, 0x9e, 0x35, 0xf3, 0xf6, 0xe6, 0xae, 0xe3, 0x3b, 0x48, 0xf8, 0xaf, 0x56, 0xa1, 0xdc, 0xc7,
+	0xee, 0x05, 0x76, 0x1b, 0x8e, 0xfd, 0xc2, 0x7a, 0xa9, 0xfe, 0x14, 0x72, 0x2d, 0x6c, 0x4e, 0xb0,
+	0x8b, 0xee, 0x02, 0xb8, 0xf8, 0x8b, 0x05, 0xf6, 0xfc, 0x91, 0x35, 0xa9, 0x49, 0x0f, 0xa4, 0xb7,
+	0x8a, 0x46, 0x91, 0x43, 0xf4, 0x09, 0x7a, 0x03, 0xf2, 0x0b, 0x0f, 0xbb, 0x04, 0x97, 0xa2, 0xb8,
+	0x1c, 0x59, 0xea, 0x13, 0x74, 0x1b, 0x8a, 0x13, 0x7c, 0x61, 0x8d, 0x31, 0x41, 0xa5, 0x29, 0xaa,
+	0xc0, 0x00, 0xfa, 0x84, 0x30, 0xa5, 0xbb, 0xcc, 0x97, 0xd8, 0xf6, 0x6b, 0x19, 0xc6, 0x94, 0x40,
+	0xea, 0x04, 0xa0, 0xfe, 0x2b, 0x05, 0x59, 0xed, 0x02, 0xdb, 0x3e, 0x7a, 0x08, 0x19, 0xff, 0x72,
+	0x8e, 0xe9, 0xb9, 0xd5, 0xfd, 0x9d, 0xbd, 0x48, 0xe4, 0x3d, 0x4a, 0x30, 0xb8, 0x9c, 0x63, 0x83,
+	0x92, 0x20, 0x04, 0x99, 0x89, 0xe9, 0x9b, 0x54, 0x8c, 0xb2, 0x41, 0xff, 0x23, 0x19, 0xd2, 0x1e,
+	0xfe, 0x82, 0x1e, 0x9f, 0x31, 0xc8, 0x5f, 0x74, 0x07, 0x8a, 0xbe, 0x35, 0xc3, 0x9e, 0x6f, 0xce,
+	0xe6, 0xf4, 0xe0, 0xb4, 0x11, 0x01, 0xd0, 0x36, 0x64, 0x7d, 0x67, 0x6e, 0x8d, 0x6b, 0x59, 0x2a,
+	0x12, 0x5b, 0xa0, 0x1d, 0xc8, 0xf9, 0xfe, 0x74, 0x34, 0xf3, 0x6a, 0x39, 0xba, 0x21, 0xeb, 0xfb,
+	0xd3, 0xb6, 0x87, 0x1e, 0x43, 0x61, 0xee, 0x5a, 0x8e, 0x6b, 0xf9, 0x97, 0xb5, 0x3c, 0x95, 0x6f,
+	0x5b, 0x94, 0xaf, 0xc7, 0x71, 0x46, 0x48, 0x85, 0xfe, 0x0b, 0xca, 0x63, 0x67, 0x3a, 0x35, 0xe7,
+	0x1e, 0x1e, 0x9d, 0xe3, 0xcb, 0x5a, 0x81, 0x9e, 0x52, 0x0a, 0x60, 0xcf, 0xf0, 0x25, 0xba, 0x05,
+	0x05, 0xa2, 0xcd, 0x68, 0xe1, 0x4e, 0x6b, 0x45, 0x8a, 0xce, 0x93, 0xf5, 0xd0, 0x9d, 0xa2, 0x2a,
+	0xa4, 0xac, 0x49, 0x0d, 0x28, 0x30, 0x65, 0x4d, 0x98, 0x67, 0xc6, 0xd8, 0x9a, 0xfb, 0x23, 0xdf,
+	0xa9, 0x95, 0x02, 0xcf, 0x50, 0xc8, 0xc0, 0xa1, 0x9c, 0x5c, 0x93, 0xd9, 0xbf, 0xcc, 0x39, 0x91,
+	0x35, 0x73, 0x9a, 0x37, 0x37, 0x6d, 0x82, 0xa9, 0x30, 0xa7, 0x91, 0xa5, 0x3e, 0x51, 0x0f, 0xa0,
+	0x68, 0xe0, 0xb1, 0x63, 0xdb, 0x78, 0xec, 0xa3, 0x9b, 0x90, 0xf3, 0x4d, 0xf7, 0x25, 0xf6, 0xb9,
+	0xd7, 0xf9, 0x8a, 0x9c, 0x7b, 0x6a, 0x8e, 0xcf, 0x9d, 0x17, 0x2f, 0x88, 0x49, 0x52, 0xcc, 0x86,
+	0x1c, 0xd2, 0xf6, 0xd4, 0x06, 0xe4, 0x9e, 0x59, 0xe3, 0x73, 0x3c, 0x21, 0x0c, 0x5c, 0x6c, 0x7a,
+	0x8e, 0x1d, 0x30, 0x60, 0x2b, 0x62, 0x86, 0x53, 0xd3, 0xb6, 0xf1, 0x64, 0xb4, 0xb0, 0x7d, 0x6b,
+	0xca, 0x59, 0x94, 0x18, 0x6c, 0x48, 0x40, 0xea, 0x43, 0x28, 0xf4, 0xf1, 0x14, 0x8f, 0x7d, 0xc7,
+	0x4d, 0x04, 0x8b, 0x94, 0x0c, 0x96, 0x1f, 0x41, 0x85, 0x86, 0x82, 0x67, 0xb0, 0xa0, 0x44, 0xbb,
+	0x90, 0x3b, 0xa3, 0xb1, 0x4b, 0x69, 0x4b, 0xfb, 0x48, 0xf4, 0x0a, 0x8b, 0x6a, 0x83, 0x53, 0xa0,
+	0xfb, 0x50, 0x72, 0xb1, 0xb7, 0x98, 0xe1, 0xd1, 0x0b, 0xd7, 0x99, 0x51, 0x49, 0x32, 0x06, 0x30,
+	0xd0, 0xa1, 0xeb, 0xcc, 0xd4, 0x5f, 0x4a, 0x50, 0xea, 0x39, 0xd3, 0xe9, 0xeb, 0x30, 0xbf, 0x09,
+	0xb9, 0xf1, 0xc2, 0xf5, 0x1c, 0x97, 0xf3, 0xe5, 0x2b, 0x62, 0xfe, 0x9f, 0x98, 0x96, 0x4f, 0xac,
+	0x97, 0xa6, 0xaa, 0xe7, 0xc8, 0xb2, 0xed, 0x11, 0x4d, 0x67, 0xe6, 0x97, 0x23, 0x4c, 0xd5, 0xa1,
+	0xd1, 0x99, 0x35, 0x8a, 0x33, 0xf3, 0x4b, 0xa6, 0x9f, 0xfa, 0x19, 0x94, 0x99, 0x28, 0xde, 0xdc,
+	0xb1, 0x3d, 0x8c, 0x1e, 0x42, 0x8e, 0x93, 0x4a, 0x0f, 0xd2, 0x6f, 0x95, 0xf6, 0x37, 0x97, 0xae,
+	0x87, 0xc1, 0x09, 0xd6, 0x89, 0xa2, 0xce, 0xa1, 0xda, 0x60, 0xee, 0x7e, 0x1d, 0x05, 0xf7, 0x61,
+	0xe7, 0x0c, 0x9b, 0xae, 0x7f, 0x8a, 0x4d, 0x7f, 0x64, 0xd9, 0x3e, 0x76, 0x2f, 0xcc, 0x69, 0x14,
+	0x14, 0x5b, 0x21, 0x52, 0xe7, 0xb8, 0xb6, 0xa7, 0x5e, 0xc2, 0x46, 0x78, 0x22, 0xd7, 0x63, 0x2d,
+	0x1b, 0x69, 0x2d, 0x1b, 0xb2, 0xc7, 0xb2, 0xcd, 0xb1, 0x6f, 0x5d, 0x58, 0xfe, 0xe5, 0x88, 0xdc,
+	0x60, 0x67, 0xe1, 0x0b, 0x47, 0x47, 0xc8, 0x01, 0xc3, 0xb5, 0x3d, 0xf5, 0xf7, 0x12, 0x54, 0xfb,
+	0xd8, 0xf3, 0x2c, 0xc7, 0xe6, 0x22, 0x7c, 0xdf, 0xda, 0x26, 0xe3, 0x2b, 0x9d, 0x8c, 0xaf, 0x28,
+	0xe3, 0x64, 0x1e, 0xa4, 0xc3, 0x8c, 0xa3, 0xfe, 0x35, 0x1d, 0x4a, 0x1a, 0xf8, 0x85, 0xdd, 0x7e,
+	0x29, 0xbc, 0xfd, 0xef, 0x41, 0x9e, 0x5f, 0x54, 0x7a, 0x7e, 0x69, 0x5f, 0x11, 0x45, 0x8f, 0xab,
+	0xd9, 0xba, 0x61, 0x04, 0xc4, 0x68, 0x17, 0xd2, 0xe6, 0xf8, 0x9c, 0x4a, 0x52, 0xda, 0xbf, 0x29,
+	0xee, 0xa9, 0x8f, 0xcf, 0xf9, 0x61, 0xad, 0x1b, 0x06, 0x21, 0x42, 0x8f, 0x21, 0xe3, 0x61, 0x7b,
+	0x52, 0xcb, 0x2c, 0x1f, 0x30, 0xb4, 0xad, 0xb1, 0xe9, 0xf9, 0xd1, 0x06, 0x4a, 0x89, 0x9e, 0x40,
+	0xd1, 0x5b, 0x9c, 0x7a, 0x63, 0xd7, 0x3a, 0xc5, 0x34, 0x89, 0x96, 0xf6, 0xef, 0xc4, 0xe4, 0x0a,
+	0x90, 0xd1, 0xc6, 0x68, 0x03, 0x3a, 0x80, 0xd2, 0xc2, 0x8e, 0xf6, 0xe7, 0xe8, 0xfe, 0x7b, 0xf1,
+	0x63, 0xbd, 0x65, 0x0e, 0xe2, 0x26, 0x22, 0x41, 0xe8, 0x88, 0x5a, 0x7e, 0x59, 0x82, 0x56, 0x80,
+	0x14, 0x24, 0x08, 0x37, 0xa0, 0xa7, 0x00, 0x13, 0xcb, 0x0b, 0x0c, 0x5b, 0xa0, 0xdb, 0xef, 0x8a,
+	0xdb, 0x9b, 0x21, 0x36, 0xda, 0x2f, 0x6c, 0x39, 0xc8, 0x43, 0xf6, 0x85, 0x6b, 0xce, 0xb0, 0x7a,
+	0x02, 0x95, 0xd0, 0x83, 0xde, 0x62, 0xea, 0xa3, 0x47, 0x90, 0xc7, 0xae, 0x3b, 0x76, 0x26, 0xab,
+	0x5f, 0x33, 0xd7, 0x75, 0xdc, 0x86, 0x33, 0xc1, 0x46, 0x40, 0x45, 0x42, 0x03, 0x13, 0x28, 0x7f,
+	0x58, 0xd9, 0x42, 0xfd, 0x4a, 0x82, 0x8d, 0x88, 0x31, 0xbb, 0x40, 0xc9, 0xd8, 0x78, 0x08, 0x59,
+	0x7a, 0xef, 0x79, 0x64, 0x2c, 0xe7, 0x85, 0xd6, 0x0d, 0x83, 0x51, 0xa0, 0x8f, 0xa1, 0xc8, 0x45,
+	0xc7, 0x13, 0x1e, 0x14, 0xb7, 0x45, 0xf2, 0xc4, 0x5d, 0x25, 0xd6, 0x0a, 0xe9, 0xd1, 0xbb, 0x24,
+	0xc1, 0x13, 0xe5, 0x78, 0x84, 0xdc, 0x5a, 0x11, 0x82, 0x4c, 0xfb, 0xd6, 0x0d, 0x83, 0x93, 0x46,
+	0x16, 0x7a, 0x0a, 0x9b, 0x4b, 0xd6, 0x7c, 0x95, 0x0b, 0xa9, 0x6e, 0x03, 0x12, 0x19, 0x30, 0x09,
+	0xd5, 0x7f, 0x48, 0x50, 0x8d, 0x47, 0xa7, 0x58, 0xa4, 0x48, 0xb1, 0x22, 0xe5, 0xcd, 0xeb, 0x0c,
+	0x15, 0x98, 0xe9, 0x43, 0xa8, 0x50, 0x0e, 0x1e, 0x7f, 0x94, 0xb8, 0xa9, 0xb6, 0xe3, 0x0a, 0x33,
+	0x9c, 0x51, 0x26, 0xa4, 0xc1, 0x0a, 0xbd, 0x09, 0x1b, 0x41, 0x01, 0xc5, 0x1f, 0x67, 0x6a, 0xad,
+	0x82, 0x51, 0xe5, 0x60, 0x83, 0x41, 0x49, 0xf6, 0x9f, 0xe0, 0xa9, 0x75, 0x41, 0x9e, 0x3a, 0x9f,
+	0x5e, 0x9e, 0xb4, 0x51, 0xe4, 0x90, 0xba, 0xaf, 0x9e, 0xc1, 0x46, 0xa8, 0x16, 0xf7, 0xfb, 0x2d,
+	0x28, 0x50, 0xf1, 0x22, 0xc5, 0xf2, 0x74, 0xad, 0x4f, 0x48, 0xe2, 0xf1, 0xc6, 0x67, 0x78, 0xb2,
+	0x98, 0xe2, 0xa8, 0x36, 0x83, 0x00, 0xa4, 0x4f, 0x62, 0xe5, 0x41, 0x3a, 0x56, 0x1e, 0xa8, 0x7f,
+	0x93, 0x20, 0x1f, 0x08, 0x75, 0xc5, 0x11, 0xaf, 0x57, 0xfa, 0xfd, 0x1f, 0xe4, 0x3c, 0xdf, 0xf4,
+	0x17, 0xec, 0x7d, 0xab, 0xc6, 0x63, 0x86, 0x9f, 0xda, 0xa7, 0x04, 0x06, 0x27, 0x8c, 0xd7, 0x6c,
+	0xd9, 0x64, 0xcd, 0x76, 0x07, 0x8a, 0xf3, 0xc5, 0xe9, 0xd4, 0xf2, 0xce, 0xb0, 0x4b, 0x53, 0x46,
+	0xd1, 0x88, 0x00, 0xea, 0x26, 0x6c, 0x70, 0xa6, 0x41, 0x7d, 0xa0, 0xfe, 0x2e, 0x05, 0x72, 0x7b,
+	0x31, 0xf5, 0xd7, 0x87, 0x48, 0xfa, 0x75, 0x42, 0xa4, 0xbf, 0x1c, 0x22, 0xe4, 0x51, 0xde, 0x13,
+	0x37, 0x24, 0x8f, 0xdd, 0x1b, 0x0a, 0x91, 0xa2, 0xd9, 0xbe, 0x7b, 0x99, 0x08, 0x9e, 0x78, 0x4c,
+	0x64, 0x12, 0x31, 0xa1, 0x0c, 0x61, 0x73, 0x89, 0x03, 0x29, 0x7a, 0x49, 0x71, 0xc9, 0xbc, 0x45,
+	0xfe, 0xa2, 0x5d, 0xc8, 0x5e, 0x98, 0xd3, 0x05, 0xae, 0xa5, 0xae, 0x88, 0x5a, 0x46, 0xf2, 0x51,
+	0xea, 0x03, 0x49, 0xfd, 0x5a, 0x82, 0x4d, 0x41, 0x54, 0x1e, 0x6d, 0x9f, 0x01, 0x95, 0x6d, 0x14,
+	0x65, 0xb1, 0xab, 0xf4, 0x63, 0x9b, 0xa8, 0x82, 0x1a, 0xdb, 0xc0, 0xf4, 0x2b, 0x2d, 0x22, 0xc8,
+	0xb5, 0x51, 0xaa, 0x0c, 0x41, 0x4e, 0x72, 0x58, 0xa1, 0xdf, 0xdb, 0xa2, 0x7e, 0x6b, 0x13, 0xab,
+	0xa0, 0xe0, 0x63, 0xa8, 0x34, 0x4c, 0x7b, 0x8c, 0xc3, 0xb2, 0x2e, 0x21, 0x88, 0x94, 0x14, 0x44,
+	0x95, 0xa1, 0x1a, 0xec, 0xe0, 0x79, 0xe6, 0x57, 0x29, 0x28, 0xf4, 0x39, 0xc1, 0x52, 0x06, 0x8e,
+	0xfb, 0x2d, 0x95, 0xf0, 0x1b, 0xfa, 0x7f, 0xc8, 0x2f, 0xd8, 0x5d, 0xae, 0xa5, 0xaf, 0x7b, 0x5b,
+	0x8d, 0x80, 0x14, 0x7d, 0x04, 0xc5, 0x59, 0x60, 0xe0, 0x5a, 0x66, 0xf9, 0x69, 0x4b, 0x46, 0x97,
+	0x11, 0x91, 0x13, 0x81, 0xcc, 0xf1, 0xd8, 0x59, 0xb0, 0x9b, 0xcc, 0xda, 0x9b, 0x22, 0x87, 0xe8,
+	0x13, 0xf4, 0x3f, 0x50, 0x0d, 0xd0, 0xde, 0xd8, 0x99, 0x63, 0xd2, 0xea, 0x90, 0x5b, 0x50, 0xe1,
+	0xd0, 0x3e, 0x05, 0xc6, 0xef, 0x5a, 0x3e, 0x79, 0xd7, 0xba, 0x50, 0xed, 0xb1, 0x45, 0x60, 0xd6,
+	0xb0, 0xba, 0x91, 0xc4, 0x7e, 0xea, 0xdb, 0x5e, 0x29, 0x72, 0x79, 0x43, 0x86, 0xdc, 0xea, 0x03,
+	0x90, 0x93, 0x35, 0xc4, 0x2b, 0x15, 0x71, 0xa1, 0x44, 0x29, 0x41, 0x22, 0x75, 0x0b, 0x36, 0x05,
+	0xae, 0xfc, 0xa8, 0x13, 0x40, 0xcb, 0xe5, 0xc6, 0x77, 0x70, 0xd8, 0x0e, 0x6c, 0xc5, 0xf8, 0xf2,
+	0xe3, 0x7e, 0x23, 0x41, 0x99, 0xc4, 0x7a, 0xcf, 0xc5, 0x1e, 0xb6, 0xc7, 0x78, 0xfd, 0xab, 0xb5,
+	0x1f, 0xa6, 0x50, 0x16, 0xef, 0x4a, 0xbc, 0xed, 0x64, 0xdb, 0x13, 0x39, 0xf4, 0x36, 0x14, 0xa7,
+	0xa6, 0xe7, 0x8f, 0x3c, 0x8c, 0x6d, 0xde, 0x75, 0x14, 0x08, 0xa0, 0x8f, 0xb1, 0x1d, 0x4f, 0xd8,
+	0xac, 0x10, 0x0d, 0x13, 0xb6, 0xfa, 0x0e, 0xa0, 0x23, 0xec, 0x07, 0x6c, 0xaf, 0xcb, 0x97, 0xea,
+	0x9f, 0x25, 0xd8, 0x8a, 0xd1, 0xf3, 0xec, 0xa1, 0x93, 0x6e, 0x99, 0xc1, 0x78, 0xe6, 0x78, 0x47,
+	0x14, 0x7b, 0xc5, 0x96, 0x50, 0x15, 0x96, 0x38, 0xc2, 0xed, 0xca, 0x10, 0x2a, 0x31, 0xd4, 0x8a,
+	0x8c, 0xb0, 0x17, 0xcf, 0x78, 0xb5, 0xd8, 0xf5, 0x12, 0x8c, 0x2c, 0x26, 0x85, 0x47, 0xb0, 0xfd,
+	0x03, 0xd3, 0x1f, 0x9f, 0x7d, 0x6b, 0x55, 0x7f, 0x0c, 0xe5, 0x43, 0xec, 0x8f, 0xc3, 0x68, 0x57,
+	0x49, 0x7b, 0x6f, 0x5f, 0x60, 0xd7, 0x33, 0x7d, 0x2b, 0xec, 0x7a, 0x63, 0x30, 0xd2, 0x88, 0x9d,
+	0xe2, 0x17, 0x8e, 0x8b, 0x83, 0x37, 0x93, 0xad, 0x48, 0xa8, 0x4c, 0xad, 0x99, 0xc5, 0xf2, 0x41,
+	0xd6, 0x60, 0x0b, 0xb5, 0x07, 0x15, 0x7e, 0x02, 0xb7, 0x62, 0x78, 0x75, 0xd6, 0x76, 0x7c, 0x0c,
+	0xbf, 0xee, 0x1c, 0xf5, 0x67, 0x50, 0x22, 0xdd, 0xf9, 0xb5, 0x95, 0x51, 0x2c, 0x24, 0x52, 0x89,
+	0x37, 0x3c, 0x6a, 0xec, 0xd3, 0x57, 0x36, 0xf6, 0x99, 0xe5, 0xc6, 0xbe, 0x0a, 0x65, 0x76, 0x3e,
+	0x8f, 0xfa, 0x3e, 0xc0, 0x81, 0x69, 0x5f, 0x2b, 0xce, 0x36, 0x64, 0xc5, 0x59, 0x01, 0x5b, 0xac,
+	0x93, 0x43, 0xad, 0x40, 0x89, 0x32, 0xe5, 0x67, 0x1c, 0x43, 0xfa, 0xc0, 0xb4, 0xbf, 0x2b, 0xe6,
+	0x9b, 0xb0, 0x71, 0x6c, 0x79, 0xfe, 0x81, 0x69, 0x87, 0x15, 0xc5, 0xfb, 0x20, 0x47, 0x20, 0xee,
+	0xa9, 0xff, 0x86, 0xcc, 0xa9, 0x69, 0x07, 0xad, 0xf9, 0x86, 0xe8, 0x28, 0x22, 0x1b, 0x45, 0xaa,
+	0xff, 0x94, 0xa0, 0xdc, 0x71, 0x7c, 0xeb, 0x85, 0x35, 0x66, 0xe1, 0x91, 0x7c, 0x47, 0xde, 0x16,
+	0x53, 0x65, 0xe2, 0x65, 0x6b, 0x39, 0xce, 0x79, 0xcc, 0xe7, 0x82, 0x82, 0xe9, 0xf5, 0xce, 0xcc,
+	0x24, 0x9c, 0x79, 0x75, 0x75, 0x85, 0x20, 0x63, 0x93, 0xc7, 0x9e, 0x15, 0x56, 0xf4, 0x7f, 0xac,
+	0x26, 0xcc, 0xc7, 0x6b, 0xc2, 0xc8, 0x68, 0x85, 0x98, 0xd1, 0x7e, 0x2d, 0x01, 0x34, 0xb1, 0x39,
+	0x39, 0xc6, 0xbe, 0x8f, 0x5d, 0xf4, 0x04, 0xca, 0xb6, 0xa0, 0x76, 0x4d, 0x5a, 0xbe, 0xa5, 0xa2,
+	0x59, 0x8c, 0x18, 0x35, 0x91, 0xe9, 0xcc, 0x71, 0xce, 0x79, 0x58, 0xd2, 0xff, 0x51, 0xb3, 0x94,
+	0x16, 0x9a, 0x25, 0xa4, 0x40, 0xc1, 0xf4, 0x7d, 0x3c, 0x9b, 0x87, 0xe3, 0x94, 0x70, 0xad, 0xee,
+	0x02, 0x8a, 0x24, 0xf2, 0x84, 0x17, 0x8b, 0xdd, 0x43, 0x29, 0x7e, 0x0f, 0xb7, 0x62, 0xb4, 0xdc,
+	0xc7, 0x1f, 0x42, 0x79, 0x82, 0xcd, 0xc9, 0x68, 0xca, 0xe0, 0xdc, 0xd7, 0xb1, 0xa6, 0x3a, 0xda,
+	0x66, 0x94, 0x26, 0x11, 0x0b, 0xf5, 0x21, 0x94, 0x07, 0xa4, 0xdc, 0x0e, 0xce, 0x15, 0xcb, 0x71,
+	0x29, 0x5e, 0x8e, 0xff, 0x36, 0x05, 0x99, 0xfe, 0xdc, 0xb4, 0xe9, 0x73, 0x42, 0x60, 0xe1, 0x6b,
+	0x4a, 0x16, 0x3c, 0x64, 0x52, 0x61, 0xc8, 0xdc, 0x84, 0xdc, 0xdc, 0x74, 0x49, 0xcc, 0xf0, 0x20,
+	0x60, 0x2b, 0xea, 0x49, 0x73, 0x86, 0xb9, 0xff, 0xe9, 0x7f, 0x54, 0x83, 0xbc, 0xe7, 0x9b, 0x2e,
+	0xe9, 0xfd, 0x98, 0xe7, 0x83, 0x25, 0xb1, 0xdc, 0x64, 0xe1, 0x32, 0xef, 0xb0, 0xa9, 0x67, 0xb8,
+	0x0e, 0x63, 0x22, 0x2f, 0xc4, 0xc4, 0x47, 0x50, 0x98, 0x61, 0xdf, 0xa4, 0x13, 0xd8, 0xc2, 0x83,
+	0x74, 0xb2, 0x6f, 0x27, 0xf2, 0xef, 0xb5, 0x39, 0x01, 0xcf, 0xe7, 0x01, 0xbd, 0xf2, 0x31, 0x54,
+	0x62, 0xa8, 0x15, 0xf9, 0x7c, 0x5b, 0xcc, 0xe7, 0x45, 0x31, 0x6b, 0xbf, 0x0f, 0x15, 0x6e, 0x48,
+	0xee, 0x94, 0xff, 0x85, 0x2c, 0x99, 0x66, 0x06, 0xde, 0x90, 0x93, 0x62, 0x18, 0x0c, 0xad, 0xb6,
+	0x00, 0x35, 0x5c, 0x6c, 0xfa, 0xf8, 0xc8, 0x75, 0x16, 0x73, 0xc1, 0x0f, 0x2f, 0xc9, 0x5a, 0xf0,
+	0x03, 0x5d, 0x27, 0xfb, 0x1d, 0xf1, 0x1d, 0xd8, 0x81, 0xad, 0x18, 0x27, 0x9e, 0x76, 0x0e, 0x41,
+	0xfe, 0xd4, 0xb1, 0xec, 0xd7, 0x62, 0x2f, 0xdc, 0x5e, 0x52, 0x9c, 0x08, 0x7c, 0x38, 0xf3, 0x23,
+	0xd8, 0x3c, 0xc6, 0xe6, 0x05, 0xfe, 0x8f, 0xb9, 0x6f, 0x03, 0x12, 0x19, 0x71, 0xf6, 0x6f, 0x43,
+	0xb5, 0x8d, 0x67, 0xa7, 0xc2, 0xc5, 0x58, 0xcf, 0x5b, 0xdd, 0x85, 0x8d, 0x90, 0x98, 0x3b, 0x61,
+	0xed, 0x9b, 0xf9, 0x8b, 0x14, 0xc8, 0x07, 0xae, 0x63, 0x4e, 0xc4, 0xe6, 0xeb, 0x0a, 0xb9, 0xbf,
+	0xd3, 0xf6, 0x2b, 0x79, 0xf0, 0x75, 0xed, 0xd7, 0xf7, 0xd5, 0x5f, 0xfd, 0x45, 0x82, 0x4d, 0x41,
+	0x96, 0x6f, 0xdf, 0x5f, 0x2d, 0x6d, 0xba, 0xba, 0xbf, 0xfa, 0xbe, 0xda, 0xa7, 0x4f, 0x40, 0x4e,
+	0x8e, 0xd1, 0x5e, 0x69, 0x70, 0xb3, 0x05, 0x9b, 0xc2, 0x7e, 0x1e, 0x72, 0x9f, 0x02, 0x44, 0x13,
+	0xc8, 0x57, 0x61, 0x17, 0x7c, 0xe5, 0x49, 0x85, 0x5f, 0x79, 0x48, 0x01, 0x50, 0x17, 0x8a, 0x8c,
+	0x0a, 0x94, 0xc8, 0xfb, 0x1c, 0x3c, 0xd7, 0x7f, 0x92, 0x20, 0xdf, 0x38, 0x23, 0x45, 0xc9, 0xf4,
+	0x35, 0x0b, 0xa0, 0x6d, 0xc8, 0x9e, 0x5a, 0xae, 0x7f, 0x16, 0xbc, 0x36, 0x74, 0x41, 0x9a, 0x28,
+	0x5a, 0x63, 0x47, 0xf3, 0x47, 0x96, 0x6b, 0x2b, 0x04, 0x1a, 0x2a, 0x4c, 0x12, 0x34, 0x9d, 0x4d,
+	0xb3, 0x01, 0x69, 0xd6, 0xe0, 0xab, 0xc4, 0x77, 0x8e, 0x5c, 0xf2, 0x3b, 0xc7, 0x53, 0x28, 0x33,
+	0x25, 0x78, 0xb8, 0x3c, 0x82, 0xc2, 0x98, 0x29, 0x11, 0xa4, 0xba, 0xad, 0xd8, 0xe0, 0x8e, 0xe1,
+	0x8c, 0x90, 0x68, 0xf7, 0x8f, 0x69, 0x28, 0x86, 0xee, 0x44, 0x5b, 0xb0, 0xa1, 0x19, 0xc6, 0x68,
+	0xd8, 0xe9, 0xf7, 0xb4, 0x86, 0x7e, 0xa8, 0x6b, 0x4d, 0xf9, 0x06, 0xda, 0x84, 0x0a, 0x01, 0x76,
+	0xba, 0x83, 0xd1, 0x61, 0x77, 0xd8, 0x69, 0xca, 0x12, 0xba, 0x09, 0x88, 0x80, 0xea, 0xc7, 0x86,
+	0x56, 0x6f, 0x3e, 0x1f, 0x69, 0x9f, 0xeb, 0xfd, 0x41, 0x5f, 0x4e, 0x05, 0xf0, 0xb6, 0xde, 0xef,
+	0xeb, 0x9d, 0xa3, 0xd1, 0xb0, 0xaf, 0x19, 0x7a, 0x53, 0x4e, 0x27, 0xe1, 0x2d, 0xad, 0xde, 0xd4,
+	0x0c, 0x39, 0x13, 0x9c, 0xd7, 0xe9, 0x8e, 0x1a, 0xdd, 0x4e, 0x7f, 0xd8, 0xd6, 0x0c, 0x39, 0x8b,
+	0x76, 0x60, 0x53, 0x24, 0xd6, 0x4e, 0xb4, 0xce, 0x40, 0xce, 0x21, 0x05, 0x6e, 0x12, 0xb0, 0xde,
+	0x39, 0xa9, 0x1f, 0xeb, 0x4d, 0x06, 0x1e, 0x0d, 0x9e, 0xf7, 0x34, 0x39, 0x8f, 0xde, 0x80, 0x2d,
+	0x71, 0xcb, 0x91, 0xd1, 0x1d, 0xf6, 0xf4, 0xa6, 0x5c, 0x48, 0xf2, 0x1a, 0x74, 0x7b, 0x7a, 0x43,
+	0x2e, 0x06, 0xf4, 0xc3, 0x4e, 0x7d, 0x38, 0x68, 0x69, 0x9d, 0x81, 0xde, 0xa8, 0x0f, 0xb4, 0xa6,
+	0x0c, 0xe8, 0x16, 0xec, 0x10, 0x44, 0x4f, 0x33, 0xe8, 0x96, 0x6e, 0x67, 0xd4, 0xd4, 0x3a, 0xc4,
+	0x0c, 0x25, 0x24, 0x43, 0x99, 0xa0, 0x9a, 0x46, 0x5d, 0xef, 0xe8, 0x9d, 0x23, 0xb9, 0x1c, 0x30,
+	0x0f, 0x24, 0x3a, 0x34, 0xea, 0x6d, 0x4d, 0xae, 0xa0, 0x6d, 0x90, 0x09, 0xd8, 0xa8, 0x0f, 0xb4,
+	0xd1, 0xb1, 0xde, 0xd6, 0x09, 0xe7, 0x6a, 0x70, 0x64, 0x40, 0xdc, 0xab, 0x3f, 0x3f, 0xee, 0xd6,
+	0x9b, 0xf2, 0x06, 0xba, 0x03, 0x35, 0x11, 0xd1, 0xe8, 0x76, 0x4e, 0x34, 0xa3, 0x5f, 0x1f, 0xe8,
+	0xdd, 0x8e, 0x2c, 0xa3, 0x2a, 0x00, 0xc1, 0x1e, 0xd4, 0x3b, 0x1d, 0xad, 0x29, 0x6f, 0xee, 0x7e,
+	0x0c, 0xd5, 0x78, 0x33, 0x47, 0x8e, 0xeb, 0x19, 0x5a, 0x5f, 0xeb, 0x34, 0xb4, 0x51, 0xf7, 0xf0,
+	0xf0, 0x58, 0xef, 0x68, 0xf2, 0x0d, 0x62, 0xd9, 0x08, 0xda, 0xa1, 0x40, 0x69, 0xf7, 0xe7, 0x50,
+	0x0c, 0x3f, 0x90, 0x52, 0xb7, 0x9e, 0x0c, 0x88, 0x2f, 0x8c, 0xc1, 0x81, 0x56, 0x1f, 0xc8, 0x37,
+	0x50, 0x19, 0x0a, 0x04, 0x34, 0xd0, 0x3e, 0x1f, 0xc8, 0x52, 0xb0, 0xfa, 0xb4, 0xdf, 0xed, 0xc8,
+	0x29, 0xaa, 0xfe, 0xc9, 0x60, 0xd4, 0x33, 0xba, 0x83, 0xee, 0xc1, 0xf0, 0x50, 0x4e, 0x53, 0xd1,
+	0x4e, 0x06, 0xa3, 0x03, 0xbd, 0x53, 0x37, 0x9e, 0xcb, 0x99, 0x80, 0xa1, 0xa1, 0x35, 0xba, 0x9d,
+	0x8e, 0xd6, 0x18, 0xc8, 0xd9, 0x80, 0xe4, 0x99, 0xde, 0x78, 0xa6, 0x35, 0xe5, 0xdc, 0xee, 0x3e,
+	0x14, 0x82, 0x2f, 0xa0, 0x4c, 0x42, 0xbd, 0x6b, 0xe8, 0x83, 0xe7, 0xa3, 0x4e, 0xd7, 0x68, 0xd7,
+	0x8f, 0x59, 0xac, 0x85, 0xc0, 0x96, 0x7e, 0xd4, 0x92, 0xa5, 0xdd, 0x53, 0x28, 0x86, 0x45, 0x2d,
+	0x42, 0x50, 0x6d, 0x75, 0xbb, 0xcf, 0x46, 0xfc, 0x08, 0x1a, 0x9f, 0x3b, 0xb0, 0x49, 0x61, 0x4d,
+	0xbd, 0x1f, 0x81, 0xa5, 0x90, 0x74, 0xa0, 0xb7, 0xb5, 0xe6, 0xa8, 0x3b, 0x1c, 0xb0, 0xf8, 0xa4,
+	0xb0, 0x61, 0xa7, 0xa9, 0x1d, 0xeb, 0x27, 0x9a, 0x51, 0x3f, 0x38, 0xd6, 0xe4, 0xf4, 0xee, 0x07,
+	0x50, 0x89, 0x4d, 0x19, 0x09, 0x4f, 0x43, 0x6b, 0x68, 0x7a, 0x6f, 0x30, 0xe2, 0x94, 0xf4, 0x28,
+	0x19, 0xca, 0x01, 0x98, 0x44, 0xbe, 0x2c, 0xed, 0x7f, 0x93, 0x86, 0x74, 0x6b, 0x71, 0x8a, 0x0e,
+	0x20, 0x1f, 0x7c, 0x3e, 0x52, 0x56, 0x8e, 0xca, 0x69, 0x96, 0x51, 0xae, 0x1a, 0xa3, 0xa3, 0x16,
+	0x14, 0xa3, 0x84, 0x70, 0xe5, 0xf7, 0x09, 0xe5, 0xee, 0x1a, 0x2c, 0xe7, 0xf4, 0x1e, 0xa4, 0xeb,
+	0xe3, 0x73, 0xb4, 0xe6, 0x4b, 0x8e, 0xf2, 0xc6, 0x12, 0x9c, 0xef, 0x7b, 0x06, 0x10, 0x0d, 0xcf,
+	0xd1, 0xd5, 0xdf, 0x38, 0x94, 0x7b, 0xeb, 0xd0, 0x91, 0x3a, 0xe1, 0xfc, 0x04, 0x5d, 0xf9, 0xc1,
+	0x47, 0xb9, 0xbb, 0x06, 0xcb, 0x39, 0x75, 0xa0, 0x24, 0x0c, 0x47, 0xd0, 0x35, 0x1f, 0x7f, 0x94,
+	0xfb, 0x6b, 0xf1, 0x61, 0x89, 0x9e, 0x21, 0x59, 0x13, 0xc5, 0xec, 0x20, 0x3c, 0x06, 0x4a, 0x6d,
+	0x19, 0xc1, 0xb6, 0xee, 0x7f, 0x25, 0x41, 0xa1, 0xef, 0xbb, 0xd8, 0x9c, 0x61, 0x17, 0x7d, 0x00,
+	0x39, 0xf6, 0x15, 0x16, 0xdd, 0x5a, 0x2a, 0x41, 0x82, 0x1a, 0x49, 0x59, 0xae, 0x4e, 0x1e, 0x4b,
+	0xe8, 0x10, 0xf2, 0xfc, 0x53, 0x08, 0x52, 0x56, 0x7e, 0x1f, 0x59, 0x11, 0x2e, 0x89, 0x0f, 0x3c,
+	0x6f, 0x49, 0x8f, 0x25, 0xa2, 0x09, 0xf9, 0xfa, 0x1b, 0xd7, 0x44, 0xf8, 0x34, 0xad, 0xd4, 0x96,
+	0x11, 0x5c, 0x93, 0xaf, 0x53, 0x50, 0xec, 0x05, 0x63, 0x3a, 0x12, 0xbf, 0x7c, 0xc2, 0x88, 0xae,
+	0x18, 0x3b, 0x2a, 0xb7, 0x57, 0xe2, 0x22, 0x87, 0x87, 0xd3, 0x46, 0x74, 0xe5, 0x10, 0x52, 0xb9,
+	0xbb, 0x06, 0xcb, 0x39, 0x1d, 0x40, 0x9e, 0x8b, 0x16, 0x97, 0x26, 0x3e, 0x49, 0x54, 0x6e, 0xaf,
+	0xc4, 0x71, 0x1e, 0x9f, 0x40, 0x21, 0x18, 0xf2, 0xa3, 0xdb, 0x2b, 0xbe, 0x27, 0x84, 0x0e, 0xda,
+	0x5a, 0x81, 0x7c, 0x2c, 0xa1, 0xa7, 0x90, 0x63, 0xc3, 0xdd, 0xb8, 0x73, 0x63, 0x23, 0x62, 0x45,
+	0x59, 0x85, 0xe2, 0x06, 0xfe, 0x26, 0x05, 0x59, 0x5a, 0x40, 0x23, 0x1d, 0x72, 0xac, 0x17, 0x88,
+	0x87, 0xee, 0x72, 0xa7, 0xa1, 0xdc, 0x5f, 0x8b, 0xe7, 0x5a, 0x35, 0x20, 0x43, 0xea, 0xfe, 0xb8,
+	0x79, 0x93, 0x1d, 0x85, 0x72, 0x77, 0x0d, 0x96, 0x33, 0x39, 0x84, 0x2c, 0x2d, 0xef, 0xe3, 0x37,
+	0x7c, 0xa9, 0x75, 0x50, 0xee, 0xad, 0x43, 0x47, 0x6e, 0xe2, 0x35, 0x7e, 0xdc, 0x4d, 0xf1, 0x2e,
+	0x41, 0xb9, 0xbd, 0x12, 0x17, 0x05, 0x4d, 0x58, 0xc0, 0xc6, 0xb5, 0x4a, 0x16, 0xe6, 0xca, 0xdd,
+	0x35, 0x58, 0x6e, 0xef, 0x3f, 0x48, 0x50, 0x08, 0xde, 0x46, 0x92, 0x32, 0x84, 0xe9, 0x61, 0xdc,
+	0xee, 0xcb, 0x93, 0x4b, 0xe5, 0xfe, 0x5a, 0x3c, 0x17, 0xb3, 0x0d, 0x95, 0xd8, 0x1c, 0x10, 0x3d,
+	0x10, 0x77, 0xac, 0x1a, 0x11, 0x2a, 0x6b, 0xe7, 0x8b, 0x8f, 0xa5, 0xfd, 0x23, 0xc8, 0xb7, 0x2c,
+	0xcf, 0x77, 0xdc, 0x4b, 0xf4, 0x04, 0xb2, 0x74, 0x9c, 0x87, 0x62, 0xf4, 0xe2, 0x0c, 0x51, 0xb9,
+	0xb5, 0x02, 0xc3, 0x95, 0xfe, 0x7b, 0x0a, 0xb2, 0xf5, 0xc9, 0xcc, 0xb2, 0x49, 0x2a, 0x20, 0x43,
+	0xb4, 0x78, 0x2a, 0x10, 0xc6, 0x7a, 0x4a, 0x6d, 0x19, 0x11, 0x3d, 0x17, 0x64, 0x16, 0x76, 0x33,
+	0x39, 0x8f, 0x5a, 0xf5, 0x5c, 0x08, 0x33, 0x34, 0xa4, 0x41, 0x21, 0x18, 0x71, 0xc5, 0xaf, 0x58,
+	0x62, 0x16, 0xa6, 0xdc, 0x59, 0x8d, 0x8c, 0xd2, 0xbb, 0x30, 0x48, 0x89, 0xfb, 0x6a, 0x79, 0x1a,
+	0xa3, 0xdc, 0x5f, 0x8b, 0xe7, 0xfc, 0x9e, 0x40, 0x96, 0x76, 0xff, 0x71, 0x8b, 0x8a, 0x93, 0x15,
+	0xe5, 0xd6, 0x0a, 0x0c, 0xdb, 0x7d, 0x70, 0xef, 0x87, 0x77, 0x5e, 0x5a, 0xfe, 0xd9, 0xe2, 0x74,
+	0x6f, 0xec, 0xcc, 0x1e, 0x99, 0xe3, 0xa9, 0xe5, 0xcd, 0x1f, 0x11, 0xea, 0x47, 0x94, 0xfa, 0x34,
+	0x47, 0x7f, 0xde, 0xfd, 0xf7, 0x00, 0xe8, 0x2c, 0x97, 0xe1, 0x36, 0x27, 0x00, 0x00,
 }
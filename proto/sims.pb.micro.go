@@ -44,7 +44,10 @@ func NewHubEndpoints() []*api.Endpoint {
 type HubService interface {
 	Connect(ctx context.Context, in *ConnectRequest, opts ...client.CallOption) (*ConnectResponse, error)
 	Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...client.CallOption) (*HeartbeatResponse, error)
+	Ack(ctx context.Context, in *AckRequest, opts ...client.CallOption) (*AckResponse, error)
 	Disconnect(ctx context.Context, in *DisconnectRequest, opts ...client.CallOption) (*DisconnectResponse, error)
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...client.CallOption) (*SubscribeResponse, error)
+	Unsubscribe(ctx context.Context, in *UnsubscribeRequest, opts ...client.CallOption) (*UnsubscribeResponse, error)
 	List(ctx context.Context, in *ListRequest, opts ...client.CallOption) (*ListResponse, error)
 }
 
@@ -80,6 +83,16 @@ func (c *hubService) Heartbeat(ctx context.Context, in *HeartbeatRequest, opts .
 	return out, nil
 }
 
+func (c *hubService) Ack(ctx context.Context, in *AckRequest, opts ...client.CallOption) (*AckResponse, error) {
+	req := c.c.NewRequest(c.name, "Hub.Ack", in)
+	out := new(AckResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *hubService) Disconnect(ctx context.Context, in *DisconnectRequest, opts ...client.CallOption) (*DisconnectResponse, error) {
 	req := c.c.NewRequest(c.name, "Hub.Disconnect", in)
 	out := new(DisconnectResponse)
@@ -90,6 +103,26 @@ func (c *hubService) Disconnect(ctx context.Context, in *DisconnectRequest, opts
 	return out, nil
 }
 
+func (c *hubService) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...client.CallOption) (*SubscribeResponse, error) {
+	req := c.c.NewRequest(c.name, "Hub.Subscribe", in)
+	out := new(SubscribeResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubService) Unsubscribe(ctx context.Context, in *UnsubscribeRequest, opts ...client.CallOption) (*UnsubscribeResponse, error) {
+	req := c.c.NewRequest(c.name, "Hub.Unsubscribe", in)
+	out := new(UnsubscribeResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *hubService) List(ctx context.Context, in *ListRequest, opts ...client.CallOption) (*ListResponse, error) {
 	req := c.c.NewRequest(c.name, "Hub.List", in)
 	out := new(ListResponse)
@@ -105,7 +138,10 @@ func (c *hubService) List(ctx context.Context, in *ListRequest, opts ...client.C
 type HubHandler interface {
 	Connect(context.Context, *ConnectRequest, *ConnectResponse) error
 	Heartbeat(context.Context, *HeartbeatRequest, *HeartbeatResponse) error
+	Ack(context.Context, *AckRequest, *AckResponse) error
 	Disconnect(context.Context, *DisconnectRequest, *DisconnectResponse) error
+	Subscribe(context.Context, *SubscribeRequest, *SubscribeResponse) error
+	Unsubscribe(context.Context, *UnsubscribeRequest, *UnsubscribeResponse) error
 	List(context.Context, *ListRequest, *ListResponse) error
 }
 
@@ -113,7 +149,10 @@ func RegisterHubHandler(s server.Server, hdlr HubHandler, opts ...server.Handler
 	type hub interface {
 		Connect(ctx context.Context, in *ConnectRequest, out *ConnectResponse) error
 		Heartbeat(ctx context.Context, in *HeartbeatRequest, out *HeartbeatResponse) error
+		Ack(ctx context.Context, in *AckRequest, out *AckResponse) error
 		Disconnect(ctx context.Context, in *DisconnectRequest, out *DisconnectResponse) error
+		Subscribe(ctx context.Context, in *SubscribeRequest, out *SubscribeResponse) error
+		Unsubscribe(ctx context.Context, in *UnsubscribeRequest, out *UnsubscribeResponse) error
 		List(ctx context.Context, in *ListRequest, out *ListResponse) error
 	}
 	type Hub struct {
@@ -135,10 +174,22 @@ func (h *hubHandler) Heartbeat(ctx context.Context, in *HeartbeatRequest, out *H
 	return h.HubHandler.Heartbeat(ctx, in, out)
 }
 
+func (h *hubHandler) Ack(ctx context.Context, in *AckRequest, out *AckResponse) error {
+	return h.HubHandler.Ack(ctx, in, out)
+}
+
 func (h *hubHandler) Disconnect(ctx context.Context, in *DisconnectRequest, out *DisconnectResponse) error {
 	return h.HubHandler.Disconnect(ctx, in, out)
 }
 
+func (h *hubHandler) Subscribe(ctx context.Context, in *SubscribeRequest, out *SubscribeResponse) error {
+	return h.HubHandler.Subscribe(ctx, in, out)
+}
+
+func (h *hubHandler) Unsubscribe(ctx context.Context, in *UnsubscribeRequest, out *UnsubscribeResponse) error {
+	return h.HubHandler.Unsubscribe(ctx, in, out)
+}
+
 func (h *hubHandler) List(ctx context.Context, in *ListRequest, out *ListResponse) error {
 	return h.HubHandler.List(ctx, in, out)
 }
@@ -153,6 +204,12 @@ func NewStreamerEndpoints() []*api.Endpoint {
 
 type StreamerService interface {
 	Events(ctx context.Context, in *EventsRequest, opts ...client.CallOption) (Streamer_EventsService, error)
+	// Session carries everything of a device over one stream: the first frame must be connect,
+	// and the events are sent back like Events.
+	Session(ctx context.Context, opts ...client.CallOption) (Streamer_SessionService, error)
+	// Poll is Events for clients without streaming, e.g. HTTP long-polling: it returns the events
+	// received within wait_ms, and the cursor to poll from next
+	Poll(ctx context.Context, in *PollRequest, opts ...client.CallOption) (*PollResponse, error)
 }
 
 type streamerService struct {
@@ -216,15 +273,84 @@ func (x *streamerServiceEvents) Recv() (*Event, error) {
 	return m, nil
 }
 
+func (c *streamerService) Session(ctx context.Context, opts ...client.CallOption) (Streamer_SessionService, error) {
+	req := c.c.NewRequest(c.name, "Streamer.Session", &SessionRequest{})
+	stream, err := c.c.Stream(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &streamerServiceSession{stream}, nil
+}
+
+type Streamer_SessionService interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Send(*SessionRequest) error
+	Recv() (*SessionResponse, error)
+}
+
+type streamerServiceSession struct {
+	stream client.Stream
+}
+
+func (x *streamerServiceSession) Close() error {
+	return x.stream.Close()
+}
+
+func (x *streamerServiceSession) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *streamerServiceSession) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *streamerServiceSession) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *streamerServiceSession) Send(m *SessionRequest) error {
+	return x.stream.Send(m)
+}
+
+func (x *streamerServiceSession) Recv() (*SessionResponse, error) {
+	m := new(SessionResponse)
+	err := x.stream.Recv(m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *streamerService) Poll(ctx context.Context, in *PollRequest, opts ...client.CallOption) (*PollResponse, error) {
+	req := c.c.NewRequest(c.name, "Streamer.Poll", in)
+	out := new(PollResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Streamer service
 
 type StreamerHandler interface {
 	Events(context.Context, *EventsRequest, Streamer_EventsStream) error
+	// Session carries everything of a device over one stream: the first frame must be connect,
+	// and the events are sent back like Events.
+	Session(context.Context, Streamer_SessionStream) error
+	// Poll is Events for clients without streaming, e.g. HTTP long-polling: it returns the events
+	// received within wait_ms, and the cursor to poll from next
+	Poll(context.Context, *PollRequest, *PollResponse) error
 }
 
 func RegisterStreamerHandler(s server.Server, hdlr StreamerHandler, opts ...server.HandlerOption) error {
 	type streamer interface {
 		Events(ctx context.Context, stream server.Stream) error
+		Session(ctx context.Context, stream server.Stream) error
+		Poll(ctx context.Context, in *PollRequest, out *PollResponse) error
 	}
 	type Streamer struct {
 		streamer
@@ -277,6 +403,55 @@ func (x *streamerEventsStream) Send(m *Event) error {
 	return x.stream.Send(m)
 }
 
+func (h *streamerHandler) Session(ctx context.Context, stream server.Stream) error {
+	return h.StreamerHandler.Session(ctx, &streamerSessionStream{stream})
+}
+
+type Streamer_SessionStream interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Send(*SessionResponse) error
+	Recv() (*SessionRequest, error)
+}
+
+type streamerSessionStream struct {
+	stream server.Stream
+}
+
+func (x *streamerSessionStream) Close() error {
+	return x.stream.Close()
+}
+
+func (x *streamerSessionStream) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *streamerSessionStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *streamerSessionStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *streamerSessionStream) Send(m *SessionResponse) error {
+	return x.stream.Send(m)
+}
+
+func (x *streamerSessionStream) Recv() (*SessionRequest, error) {
+	m := new(SessionRequest)
+	if err := x.stream.Recv(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (h *streamerHandler) Poll(ctx context.Context, in *PollRequest, out *PollResponse) error {
+	return h.StreamerHandler.Poll(ctx, in, out)
+}
+
 // Api Endpoints for Publisher service
 
 func NewPublisherEndpoints() []*api.Endpoint {
@@ -288,6 +463,11 @@ func NewPublisherEndpoints() []*api.Endpoint {
 type PublisherService interface {
 	Unicast(ctx context.Context, in *UnicastRequest, opts ...client.CallOption) (*UnicastResponse, error)
 	Multicast(ctx context.Context, in *MulticastRequest, opts ...client.CallOption) (*MulticastResponse, error)
+	Publish(ctx context.Context, in *PublishRequest, opts ...client.CallOption) (*PublishResponse, error)
+	// Receipts streams the receipts of the events published by the caller with request_receipt
+	Receipts(ctx context.Context, in *ReceiptsRequest, opts ...client.CallOption) (Publisher_ReceiptsService, error)
+	// Cancel cancels a delivery scheduled by deliver_at
+	Cancel(ctx context.Context, in *CancelRequest, opts ...client.CallOption) (*CancelResponse, error)
 }
 
 type publisherService struct {
@@ -322,17 +502,94 @@ func (c *publisherService) Multicast(ctx context.Context, in *MulticastRequest,
 	return out, nil
 }
 
+func (c *publisherService) Publish(ctx context.Context, in *PublishRequest, opts ...client.CallOption) (*PublishResponse, error) {
+	req := c.c.NewRequest(c.name, "Publisher.Publish", in)
+	out := new(PublishResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *publisherService) Receipts(ctx context.Context, in *ReceiptsRequest, opts ...client.CallOption) (Publisher_ReceiptsService, error) {
+	req := c.c.NewRequest(c.name, "Publisher.Receipts", &ReceiptsRequest{})
+	stream, err := c.c.Stream(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.Send(in); err != nil {
+		return nil, err
+	}
+	return &publisherServiceReceipts{stream}, nil
+}
+
+type Publisher_ReceiptsService interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Recv() (*Receipt, error)
+}
+
+type publisherServiceReceipts struct {
+	stream client.Stream
+}
+
+func (x *publisherServiceReceipts) Close() error {
+	return x.stream.Close()
+}
+
+func (x *publisherServiceReceipts) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *publisherServiceReceipts) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *publisherServiceReceipts) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *publisherServiceReceipts) Recv() (*Receipt, error) {
+	m := new(Receipt)
+	err := x.stream.Recv(m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *publisherService) Cancel(ctx context.Context, in *CancelRequest, opts ...client.CallOption) (*CancelResponse, error) {
+	req := c.c.NewRequest(c.name, "Publisher.Cancel", in)
+	out := new(CancelResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Publisher service
 
 type PublisherHandler interface {
 	Unicast(context.Context, *UnicastRequest, *UnicastResponse) error
 	Multicast(context.Context, *MulticastRequest, *MulticastResponse) error
+	Publish(context.Context, *PublishRequest, *PublishResponse) error
+	// Receipts streams the receipts of the events published by the caller with request_receipt
+	Receipts(context.Context, *ReceiptsRequest, Publisher_ReceiptsStream) error
+	// Cancel cancels a delivery scheduled by deliver_at
+	Cancel(context.Context, *CancelRequest, *CancelResponse) error
 }
 
 func RegisterPublisherHandler(s server.Server, hdlr PublisherHandler, opts ...server.HandlerOption) error {
 	type publisher interface {
 		Unicast(ctx context.Context, in *UnicastRequest, out *UnicastResponse) error
 		Multicast(ctx context.Context, in *MulticastRequest, out *MulticastResponse) error
+		Publish(ctx context.Context, in *PublishRequest, out *PublishResponse) error
+		Receipts(ctx context.Context, stream server.Stream) error
+		Cancel(ctx context.Context, in *CancelRequest, out *CancelResponse) error
 	}
 	type Publisher struct {
 		publisher
@@ -352,3 +609,527 @@ func (h *publisherHandler) Unicast(ctx context.Context, in *UnicastRequest, out
 func (h *publisherHandler) Multicast(ctx context.Context, in *MulticastRequest, out *MulticastResponse) error {
 	return h.PublisherHandler.Multicast(ctx, in, out)
 }
+
+func (h *publisherHandler) Publish(ctx context.Context, in *PublishRequest, out *PublishResponse) error {
+	return h.PublisherHandler.Publish(ctx, in, out)
+}
+
+func (h *publisherHandler) Receipts(ctx context.Context, stream server.Stream) error {
+	m := new(ReceiptsRequest)
+	if err := stream.Recv(m); err != nil {
+		return err
+	}
+	return h.PublisherHandler.Receipts(ctx, m, &publisherReceiptsStream{stream})
+}
+
+type Publisher_ReceiptsStream interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Send(*Receipt) error
+}
+
+type publisherReceiptsStream struct {
+	stream server.Stream
+}
+
+func (x *publisherReceiptsStream) Close() error {
+	return x.stream.Close()
+}
+
+func (x *publisherReceiptsStream) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *publisherReceiptsStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *publisherReceiptsStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *publisherReceiptsStream) Send(m *Receipt) error {
+	return x.stream.Send(m)
+}
+
+func (h *publisherHandler) Cancel(ctx context.Context, in *CancelRequest, out *CancelResponse) error {
+	return h.PublisherHandler.Cancel(ctx, in, out)
+}
+
+// Api Endpoints for Group service
+
+func NewGroupEndpoints() []*api.Endpoint {
+	return []*api.Endpoint{}
+}
+
+// Client API for Group service
+
+type GroupService interface {
+	Create(ctx context.Context, in *CreateGroupRequest, opts ...client.CallOption) (*CreateGroupResponse, error)
+	Join(ctx context.Context, in *JoinGroupRequest, opts ...client.CallOption) (*JoinGroupResponse, error)
+	Leave(ctx context.Context, in *LeaveGroupRequest, opts ...client.CallOption) (*LeaveGroupResponse, error)
+	Members(ctx context.Context, in *MembersRequest, opts ...client.CallOption) (*MembersResponse, error)
+	Broadcast(ctx context.Context, in *BroadcastRequest, opts ...client.CallOption) (*BroadcastResponse, error)
+}
+
+type groupService struct {
+	c    client.Client
+	name string
+}
+
+func NewGroupService(name string, c client.Client) GroupService {
+	return &groupService{
+		c:    c,
+		name: name,
+	}
+}
+
+func (c *groupService) Create(ctx context.Context, in *CreateGroupRequest, opts ...client.CallOption) (*CreateGroupResponse, error) {
+	req := c.c.NewRequest(c.name, "Group.Create", in)
+	out := new(CreateGroupResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *groupService) Join(ctx context.Context, in *JoinGroupRequest, opts ...client.CallOption) (*JoinGroupResponse, error) {
+	req := c.c.NewRequest(c.name, "Group.Join", in)
+	out := new(JoinGroupResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *groupService) Leave(ctx context.Context, in *LeaveGroupRequest, opts ...client.CallOption) (*LeaveGroupResponse, error) {
+	req := c.c.NewRequest(c.name, "Group.Leave", in)
+	out := new(LeaveGroupResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *groupService) Members(ctx context.Context, in *MembersRequest, opts ...client.CallOption) (*MembersResponse, error) {
+	req := c.c.NewRequest(c.name, "Group.Members", in)
+	out := new(MembersResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *groupService) Broadcast(ctx context.Context, in *BroadcastRequest, opts ...client.CallOption) (*BroadcastResponse, error) {
+	req := c.c.NewRequest(c.name, "Group.Broadcast", in)
+	out := new(BroadcastResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Group service
+
+type GroupHandler interface {
+	Create(context.Context, *CreateGroupRequest, *CreateGroupResponse) error
+	Join(context.Context, *JoinGroupRequest, *JoinGroupResponse) error
+	Leave(context.Context, *LeaveGroupRequest, *LeaveGroupResponse) error
+	Members(context.Context, *MembersRequest, *MembersResponse) error
+	Broadcast(context.Context, *BroadcastRequest, *BroadcastResponse) error
+}
+
+func RegisterGroupHandler(s server.Server, hdlr GroupHandler, opts ...server.HandlerOption) error {
+	type group interface {
+		Create(ctx context.Context, in *CreateGroupRequest, out *CreateGroupResponse) error
+		Join(ctx context.Context, in *JoinGroupRequest, out *JoinGroupResponse) error
+		Leave(ctx context.Context, in *LeaveGroupRequest, out *LeaveGroupResponse) error
+		Members(ctx context.Context, in *MembersRequest, out *MembersResponse) error
+		Broadcast(ctx context.Context, in *BroadcastRequest, out *BroadcastResponse) error
+	}
+	type Group struct {
+		group
+	}
+	h := &groupHandler{hdlr}
+	return s.Handle(s.NewHandler(&Group{h}, opts...))
+}
+
+type groupHandler struct {
+	GroupHandler
+}
+
+func (h *groupHandler) Create(ctx context.Context, in *CreateGroupRequest, out *CreateGroupResponse) error {
+	return h.GroupHandler.Create(ctx, in, out)
+}
+
+func (h *groupHandler) Join(ctx context.Context, in *JoinGroupRequest, out *JoinGroupResponse) error {
+	return h.GroupHandler.Join(ctx, in, out)
+}
+
+func (h *groupHandler) Leave(ctx context.Context, in *LeaveGroupRequest, out *LeaveGroupResponse) error {
+	return h.GroupHandler.Leave(ctx, in, out)
+}
+
+func (h *groupHandler) Members(ctx context.Context, in *MembersRequest, out *MembersResponse) error {
+	return h.GroupHandler.Members(ctx, in, out)
+}
+
+func (h *groupHandler) Broadcast(ctx context.Context, in *BroadcastRequest, out *BroadcastResponse) error {
+	return h.GroupHandler.Broadcast(ctx, in, out)
+}
+
+// Api Endpoints for Presence service
+
+func NewPresenceEndpoints() []*api.Endpoint {
+	return []*api.Endpoint{}
+}
+
+// Client API for Presence service
+
+type PresenceService interface {
+	GetPresence(ctx context.Context, in *GetPresenceRequest, opts ...client.CallOption) (*GetPresenceResponse, error)
+	// WatchPresence streams the current presence of the users, then every change
+	WatchPresence(ctx context.Context, in *WatchPresenceRequest, opts ...client.CallOption) (Presence_WatchPresenceService, error)
+}
+
+type presenceService struct {
+	c    client.Client
+	name string
+}
+
+func NewPresenceService(name string, c client.Client) PresenceService {
+	return &presenceService{
+		c:    c,
+		name: name,
+	}
+}
+
+func (c *presenceService) GetPresence(ctx context.Context, in *GetPresenceRequest, opts ...client.CallOption) (*GetPresenceResponse, error) {
+	req := c.c.NewRequest(c.name, "Presence.GetPresence", in)
+	out := new(GetPresenceResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *presenceService) WatchPresence(ctx context.Context, in *WatchPresenceRequest, opts ...client.CallOption) (Presence_WatchPresenceService, error) {
+	req := c.c.NewRequest(c.name, "Presence.WatchPresence", &WatchPresenceRequest{})
+	stream, err := c.c.Stream(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.Send(in); err != nil {
+		return nil, err
+	}
+	return &presenceServiceWatchPresence{stream}, nil
+}
+
+type Presence_WatchPresenceService interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Recv() (*UserPresence, error)
+}
+
+type presenceServiceWatchPresence struct {
+	stream client.Stream
+}
+
+func (x *presenceServiceWatchPresence) Close() error {
+	return x.stream.Close()
+}
+
+func (x *presenceServiceWatchPresence) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *presenceServiceWatchPresence) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *presenceServiceWatchPresence) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *presenceServiceWatchPresence) Recv() (*UserPresence, error) {
+	m := new(UserPresence)
+	err := x.stream.Recv(m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Server API for Presence service
+
+type PresenceHandler interface {
+	GetPresence(context.Context, *GetPresenceRequest, *GetPresenceResponse) error
+	// WatchPresence streams the current presence of the users, then every change
+	WatchPresence(context.Context, *WatchPresenceRequest, Presence_WatchPresenceStream) error
+}
+
+func RegisterPresenceHandler(s server.Server, hdlr PresenceHandler, opts ...server.HandlerOption) error {
+	type presence interface {
+		GetPresence(ctx context.Context, in *GetPresenceRequest, out *GetPresenceResponse) error
+		WatchPresence(ctx context.Context, stream server.Stream) error
+	}
+	type Presence struct {
+		presence
+	}
+	h := &presenceHandler{hdlr}
+	return s.Handle(s.NewHandler(&Presence{h}, opts...))
+}
+
+type presenceHandler struct {
+	PresenceHandler
+}
+
+func (h *presenceHandler) GetPresence(ctx context.Context, in *GetPresenceRequest, out *GetPresenceResponse) error {
+	return h.PresenceHandler.GetPresence(ctx, in, out)
+}
+
+func (h *presenceHandler) WatchPresence(ctx context.Context, stream server.Stream) error {
+	m := new(WatchPresenceRequest)
+	if err := stream.Recv(m); err != nil {
+		return err
+	}
+	return h.PresenceHandler.WatchPresence(ctx, m, &presenceWatchPresenceStream{stream})
+}
+
+type Presence_WatchPresenceStream interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Send(*UserPresence) error
+}
+
+type presenceWatchPresenceStream struct {
+	stream server.Stream
+}
+
+func (x *presenceWatchPresenceStream) Close() error {
+	return x.stream.Close()
+}
+
+func (x *presenceWatchPresenceStream) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *presenceWatchPresenceStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *presenceWatchPresenceStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *presenceWatchPresenceStream) Send(m *UserPresence) error {
+	return x.stream.Send(m)
+}
+
+// Api Endpoints for History service
+
+func NewHistoryEndpoints() []*api.Endpoint {
+	return []*api.Endpoint{}
+}
+
+// Client API for History service
+
+type HistoryService interface {
+	// Fetch returns a page of the events of a conversation, from the latest backwards
+	Fetch(ctx context.Context, in *FetchRequest, opts ...client.CallOption) (*FetchResponse, error)
+}
+
+type historyService struct {
+	c    client.Client
+	name string
+}
+
+func NewHistoryService(name string, c client.Client) HistoryService {
+	return &historyService{
+		c:    c,
+		name: name,
+	}
+}
+
+func (c *historyService) Fetch(ctx context.Context, in *FetchRequest, opts ...client.CallOption) (*FetchResponse, error) {
+	req := c.c.NewRequest(c.name, "History.Fetch", in)
+	out := new(FetchResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for History service
+
+type HistoryHandler interface {
+	// Fetch returns a page of the events of a conversation, from the latest backwards
+	Fetch(context.Context, *FetchRequest, *FetchResponse) error
+}
+
+func RegisterHistoryHandler(s server.Server, hdlr HistoryHandler, opts ...server.HandlerOption) error {
+	type history interface {
+		Fetch(ctx context.Context, in *FetchRequest, out *FetchResponse) error
+	}
+	type History struct {
+		history
+	}
+	h := &historyHandler{hdlr}
+	return s.Handle(s.NewHandler(&History{h}, opts...))
+}
+
+type historyHandler struct {
+	HistoryHandler
+}
+
+func (h *historyHandler) Fetch(ctx context.Context, in *FetchRequest, out *FetchResponse) error {
+	return h.HistoryHandler.Fetch(ctx, in, out)
+}
+
+// Api Endpoints for Admin service
+
+func NewAdminEndpoints() []*api.Endpoint {
+	return []*api.Endpoint{}
+}
+
+// Client API for Admin service
+
+type AdminService interface {
+	// Kick closes the channel of a device, or of every device if device_id is empty
+	Kick(ctx context.Context, in *KickRequest, opts ...client.CallOption) (*KickResponse, error)
+	// Ban kicks a user, and rejects its Connect until then
+	Ban(ctx context.Context, in *BanRequest, opts ...client.CallOption) (*BanResponse, error)
+	ListBans(ctx context.Context, in *ListBansRequest, opts ...client.CallOption) (*ListBansResponse, error)
+	// DeadLetters returns the latest notifications failed on the hooks
+	DeadLetters(ctx context.Context, in *DeadLettersRequest, opts ...client.CallOption) (*DeadLettersResponse, error)
+	// Trace returns the spans of a trace recorded by every node, e.g. the publishing and delivery of an event
+	Trace(ctx context.Context, in *TraceRequest, opts ...client.CallOption) (*TraceResponse, error)
+}
+
+type adminService struct {
+	c    client.Client
+	name string
+}
+
+func NewAdminService(name string, c client.Client) AdminService {
+	return &adminService{
+		c:    c,
+		name: name,
+	}
+}
+
+func (c *adminService) Kick(ctx context.Context, in *KickRequest, opts ...client.CallOption) (*KickResponse, error) {
+	req := c.c.NewRequest(c.name, "Admin.Kick", in)
+	out := new(KickResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminService) Ban(ctx context.Context, in *BanRequest, opts ...client.CallOption) (*BanResponse, error) {
+	req := c.c.NewRequest(c.name, "Admin.Ban", in)
+	out := new(BanResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminService) ListBans(ctx context.Context, in *ListBansRequest, opts ...client.CallOption) (*ListBansResponse, error) {
+	req := c.c.NewRequest(c.name, "Admin.ListBans", in)
+	out := new(ListBansResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminService) DeadLetters(ctx context.Context, in *DeadLettersRequest, opts ...client.CallOption) (*DeadLettersResponse, error) {
+	req := c.c.NewRequest(c.name, "Admin.DeadLetters", in)
+	out := new(DeadLettersResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminService) Trace(ctx context.Context, in *TraceRequest, opts ...client.CallOption) (*TraceResponse, error) {
+	req := c.c.NewRequest(c.name, "Admin.Trace", in)
+	out := new(TraceResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Admin service
+
+type AdminHandler interface {
+	// Kick closes the channel of a device, or of every device if device_id is empty
+	Kick(context.Context, *KickRequest, *KickResponse) error
+	// Ban kicks a user, and rejects its Connect until then
+	Ban(context.Context, *BanRequest, *BanResponse) error
+	ListBans(context.Context, *ListBansRequest, *ListBansResponse) error
+	// DeadLetters returns the latest notifications failed on the hooks
+	DeadLetters(context.Context, *DeadLettersRequest, *DeadLettersResponse) error
+	// Trace returns the spans of a trace recorded by every node, e.g. the publishing and delivery of an event
+	Trace(context.Context, *TraceRequest, *TraceResponse) error
+}
+
+func RegisterAdminHandler(s server.Server, hdlr AdminHandler, opts ...server.HandlerOption) error {
+	type admin interface {
+		Kick(ctx context.Context, in *KickRequest, out *KickResponse) error
+		Ban(ctx context.Context, in *BanRequest, out *BanResponse) error
+		ListBans(ctx context.Context, in *ListBansRequest, out *ListBansResponse) error
+		DeadLetters(ctx context.Context, in *DeadLettersRequest, out *DeadLettersResponse) error
+		Trace(ctx context.Context, in *TraceRequest, out *TraceResponse) error
+	}
+	type Admin struct {
+		admin
+	}
+	h := &adminHandler{hdlr}
+	return s.Handle(s.NewHandler(&Admin{h}, opts...))
+}
+
+type adminHandler struct {
+	AdminHandler
+}
+
+func (h *adminHandler) Kick(ctx context.Context, in *KickRequest, out *KickResponse) error {
+	return h.AdminHandler.Kick(ctx, in, out)
+}
+
+func (h *adminHandler) Ban(ctx context.Context, in *BanRequest, out *BanResponse) error {
+	return h.AdminHandler.Ban(ctx, in, out)
+}
+
+func (h *adminHandler) ListBans(ctx context.Context, in *ListBansRequest, out *ListBansResponse) error {
+	return h.AdminHandler.ListBans(ctx, in, out)
+}
+
+func (h *adminHandler) DeadLetters(ctx context.Context, in *DeadLettersRequest, out *DeadLettersResponse) error {
+	return h.AdminHandler.DeadLetters(ctx, in, out)
+}
+
+func (h *adminHandler) Trace(ctx context.Context, in *TraceRequest, out *TraceResponse) error {
+	return h.AdminHandler.Trace(ctx, in, out)
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/aclisp/sims/proto"
+	"github.com/golang/protobuf/jsonpb"
+	pb "github.com/golang/protobuf/proto"
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/logger"
+	"github.com/micro/go-micro/v2/metadata"
+	"github.com/micro/go-micro/v2/store"
+)
+
+const (
+	// KickGrace is how long a kicked channel being streamed waits for EVT_KICKED to be sent, before it is closed
+	KickGrace = 5 * time.Second
+
+	banPrefix = "ban"
+	// adminScope is the scope of the accounts of operators, which may call Admin
+	adminScope = "admin"
+)
+
+// Admin lets operators kick and ban users. Bans are kept in the store, so that every node honors them.
+type Admin struct {
+	store store.Store
+	pub   *Publisher
+}
+
+// NewAdmin creates the admin service keeping bans in s, and locating users by pub
+func NewAdmin(s store.Store, pub *Publisher) *Admin {
+	return &Admin{
+		store: s,
+		pub:   pub,
+	}
+}
+
+func errorBanned(userID string, until int64) error {
+	return errors.Forbidden(proto.ErrorCode_ERR_BANNED.String(), "%v is banned until %v", userID,
+		time.Unix(0, until*int64(time.Millisecond)).UTC().Format(time.RFC3339))
+}
+
+// allowAdmin tells if the caller is an operator
+func allowAdmin(ctx context.Context) error {
+	account, err := authenticate(ctx)
+	if err != nil || account == nil {
+		// authentication is disabled if both are nil
+		return err
+	}
+	if hasScope(account, adminScope) {
+		return nil
+	}
+	return errorPermissionDenied("%v is not of the %q scope", account.ID, adminScope)
+}
+
+// kickedEvent tells a client why its channel is closed
+func kickedEvent(reason string, bannedUntil int64) *proto.Event {
+	m := jsonpb.Marshaler{OrigName: true}
+	data, _ := m.MarshalToString(&proto.Kicked{Reason: reason, BannedUntil: bannedUntil})
+	return &proto.Event{
+		Type:      proto.EventType_EVT_KICKED,
+		Data:      []byte(data),
+		Timestamp: time.Now().UnixNano() / int64(time.Millisecond),
+		Priority:  proto.Priority_PRIORITY_HIGH,
+	}
+}
+
+// kick closes the channels of a user connected to this node after telling them why, or only the channel of
+// deviceID if not empty. A channel being streamed is closed once EVT_KICKED is sent, or after KickGrace.
+func (reg *Registrar) kick(userID, deviceID, reason string, bannedUntil int64) {
+	event := kickedEvent(reason, bannedUntil)
+	for uid := range reg.findChannels(userID, nil) {
+		if deviceID != "" && uid.DeviceID != deviceID {
+			continue
+		}
+		channel := reg.removeEventQueue(uid)
+		if channel == nil {
+			continue
+		}
+		metricDisconnects.Inc("kicked")
+		gHooks.notify(proto.HookEvent_HOOK_DISCONNECTED, uid, "", "kicked: "+reason)
+		logger.Infof("[%v] kicked", uid)
+		if channel.Active.Load() == 0 || !channel.offer(event) {
+			reg.closeKicked(channel)
+			continue
+		}
+		time.AfterFunc(KickGrace, func() { reg.closeKicked(channel) })
+	}
+}
+
+// closeKicked closes a kicked channel once, and keeps the events after EVT_KICKED in the mailbox
+func (reg *Registrar) closeKicked(channel *Channel) {
+	if !channel.kicked.CAS(false, true) {
+		return
+	}
+	channel.closeQueue()
+	reg.release(channel.uid, channel)
+}
+
+// kick closes the channels of a user on every node it is connected to
+func (a *Admin) kick(ctx context.Context, req *proto.KickRequest) error {
+	gRegistrar.kick(req.UserId, req.DeviceId, req.Reason, req.BannedUntil)
+	var err error
+	for _, address := range a.pub.locate(ctx, req.UserId) {
+		ctx := metadata.Set(ctx, metadataForwarded, gAddress)
+		node := proto.NewAdminService(MicroServiceName, gService.Client())
+		if _, ferr := node.Kick(ctx, req, client.WithAddress(address)); ferr != nil {
+			logger.Errorf("[%v] forward kick to %v error: %v", req.UserId, address, ferr)
+			err = ferr
+		}
+	}
+	return err
+}
+
+// checkBan rejects a banned user
+func (a *Admin) checkBan(userID string) error {
+	if a == nil {
+		return nil
+	}
+	records, err := a.store.Read(storeKey(banPrefix, userID))
+	if err != nil || len(records) == 0 {
+		if err != nil && err != store.ErrNotFound {
+			logger.Errorf("[%v] read ban error: %v", userID, err)
+		}
+		return nil
+	}
+	ban := new(proto.Ban)
+	if err := pb.Unmarshal(records[0].Value, ban); err != nil {
+		logger.Errorf("[%v] decode ban error: %v", userID, err)
+		return nil
+	}
+	if ban.Until <= time.Now().UnixNano()/int64(time.Millisecond) {
+		return nil
+	}
+	return errorBanned(userID, ban.Until)
+}
+
+// Kick TODO
+func (a *Admin) Kick(ctx context.Context, req *proto.KickRequest, res *proto.KickResponse) error {
+	if req.UserId == "" {
+		return errors.BadRequest(proto.ErrorCode_ERR_MISSING_USERID.String(), "")
+	}
+	if err := allowAdmin(ctx); err != nil {
+		return err
+	}
+	if !isForwarded(ctx) {
+		// only a ban tells its expiry
+		req.BannedUntil = 0
+	}
+	return a.kick(ctx, req)
+}
+
+// Ban TODO
+func (a *Admin) Ban(ctx context.Context, req *proto.BanRequest, res *proto.BanResponse) error {
+	if req.UserId == "" {
+		return errors.BadRequest(proto.ErrorCode_ERR_MISSING_USERID.String(), "")
+	}
+	if err := allowAdmin(ctx); err != nil {
+		return err
+	}
+	key := storeKey(banPrefix, req.UserId)
+	ttl := time.Until(time.Unix(0, req.Until*int64(time.Millisecond)))
+	if ttl <= 0 {
+		if err := a.store.Delete(key); err != nil && err != store.ErrNotFound {
+			return errorInternal("delete ban of %v: %v", req.UserId, err)
+		}
+		logger.Infof("[%v] unbanned", req.UserId)
+		return nil
+	}
+	value, err := pb.Marshal(&proto.Ban{UserId: req.UserId, Until: req.Until, Reason: req.Reason})
+	if err != nil {
+		return errorInternal("encode ban: %v", err)
+	}
+	if err := a.store.Write(&store.Record{Key: key, Value: value, Expiry: ttl}); err != nil {
+		return errorInternal("write ban of %v: %v", req.UserId, err)
+	}
+	logger.Infof("[%v] banned for %v: %v", req.UserId, ttl.Round(time.Second), req.Reason)
+	return a.kick(ctx, &proto.KickRequest{UserId: req.UserId, Reason: req.Reason, BannedUntil: req.Until})
+}
+
+// ListBans TODO
+func (a *Admin) ListBans(ctx context.Context, req *proto.ListBansRequest, res *proto.ListBansResponse) error {
+	if err := allowAdmin(ctx); err != nil {
+		return err
+	}
+	prefix := storeKey(banPrefix) + "/"
+	records, err := a.store.Read(prefix, store.ReadPrefix())
+	if err != nil && err != store.ErrNotFound {
+		return errorInternal("read bans: %v", err)
+	}
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+	for _, record := range records {
+		ban := new(proto.Ban)
+		if err := pb.Unmarshal(record.Value, ban); err != nil {
+			logger.Errorf("decode ban %q error: %v", record.Key, err)
+			continue
+		}
+		if ban.Until > now {
+			res.Bans = append(res.Bans, ban)
+		}
+	}
+	return nil
+}
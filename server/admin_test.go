@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aclisp/sims/proto"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/micro/go-micro/v2/store/memory"
+)
+
+// sink collects the events sent to a stream
+type sink chan *proto.Event
+
+func (s sink) Send(event *proto.Event) error {
+	s <- event
+	return nil
+}
+
+// streamEvents streams the channel of uid to the returned sink, which is closed when the stream ends
+func streamEvents(t *testing.T, uid UniqueID) sink {
+	channel := gRegistrar.findChannel(uid)
+	events := make(sink, 100)
+	go func() {
+		defer close(events)
+		if err := gRegistrar.stream(context.Background(), uid, "test", channel, 0, events); err != nil {
+			t.Error(err)
+		}
+	}()
+	for channel.Active.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	return events
+}
+
+// kicked returns the Kicked of the first non-heartbeat event of a stream, which should be the last
+func kicked(t *testing.T, events sink) *proto.Kicked {
+	var got []*proto.Event
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				if len(got) != 1 || got[0].Type != proto.EventType_EVT_KICKED {
+					t.Fatalf("streamed %v, want only %v", got, proto.EventType_EVT_KICKED)
+				}
+				k := new(proto.Kicked)
+				if err := jsonpb.UnmarshalString(string(got[0].Data), k); err != nil {
+					t.Fatal(err)
+				}
+				return k
+			}
+			if event.Type != proto.EventType_EVT_HEARTBEAT {
+				got = append(got, event)
+			}
+		case <-timeout:
+			t.Fatalf("stream is open after streaming %v", got)
+		}
+	}
+}
+
+func TestBan(t *testing.T) {
+	uids := newTestRegistrar(2)
+	gAdmin = NewAdmin(memory.NewStore(), new(Publisher))
+	defer func() { gAdmin = nil }()
+
+	events := streamEvents(t, uids[0])
+	until := time.Now().Add(time.Hour).UnixNano() / int64(time.Millisecond)
+	if err := gAdmin.Ban(context.Background(), &proto.BanRequest{UserId: uids[0].UserID, Until: until, Reason: "spam"}, new(proto.BanResponse)); err != nil {
+		t.Fatal(err)
+	}
+	if gRegistrar.findChannel(uids[0]) != nil {
+		t.Fatalf("%v is connected after banned", uids[0])
+	}
+	if k := kicked(t, events); k.Reason != "spam" || k.BannedUntil != until {
+		t.Fatalf("kicked by %v, want the ban until %v", k, until)
+	}
+	if gRegistrar.findChannel(uids[1]) == nil {
+		t.Fatalf("%v is kicked, want connected", uids[1])
+	}
+
+	header := &proto.Header{UserId: uids[0].UserID}
+	err := gRegistrar.Connect(context.Background(), &proto.ConnectRequest{Header: header}, new(proto.ConnectResponse))
+	if errorCode(err) != proto.ErrorCode_ERR_BANNED {
+		t.Fatalf("connect when banned: %v, want %v", err, proto.ErrorCode_ERR_BANNED)
+	}
+	bans := new(proto.ListBansResponse)
+	if err := gAdmin.ListBans(context.Background(), new(proto.ListBansRequest), bans); err != nil {
+		t.Fatal(err)
+	}
+	if len(bans.Bans) != 1 || bans.Bans[0].UserId != uids[0].UserID || bans.Bans[0].Until != until {
+		t.Fatalf("listed %v, want the ban of %v", bans.Bans, uids[0])
+	}
+
+	// a past time lifts the ban
+	if err := gAdmin.Ban(context.Background(), &proto.BanRequest{UserId: uids[0].UserID}, new(proto.BanResponse)); err != nil {
+		t.Fatal(err)
+	}
+	if err := gRegistrar.Connect(context.Background(), &proto.ConnectRequest{Header: header}, new(proto.ConnectResponse)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestKick(t *testing.T) {
+	uids := newTestRegistrar(1)
+	gAdmin = NewAdmin(memory.NewStore(), new(Publisher))
+	gMailbox = NewMailbox(memory.NewStore(), time.Hour)
+	defer func() { gAdmin, gMailbox = nil, nil }()
+
+	channel := gRegistrar.findChannel(uids[0])
+	events := streamEvents(t, uids[0])
+	// only a kick forwarded by the node banning tells the ban
+	if err := gAdmin.Kick(context.Background(), &proto.KickRequest{UserId: uids[0].UserID, BannedUntil: 1}, new(proto.KickResponse)); err != nil {
+		t.Fatal(err)
+	}
+	// a publisher may still hold the channel found before the kick
+	if err := push(uids[0], channel, &proto.Event{Type: proto.EventType_EVT_TEXT, Data: []byte("after")}); err != nil {
+		t.Fatal(err)
+	}
+	if k := kicked(t, events); k.BannedUntil != 0 {
+		t.Fatalf("kicked by %v, want no ban", k)
+	}
+	if gRegistrar.findChannel(uids[0]) != nil {
+		t.Fatalf("%v is connected after kicked", uids[0])
+	}
+
+	// the events after the kick are kept for the next channel, but not the kick
+	kept, err := gMailbox.Take(uids[0], new(Channel))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var data []string
+	for _, event := range kept {
+		data = append(data, string(event.Data))
+	}
+	if strings.Join(data, ",") != "after" {
+		t.Fatalf("kept %q in the mailbox, want only \"after\"", data)
+	}
+}
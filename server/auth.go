@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/micro/go-micro/v2/auth"
+	"github.com/micro/go-micro/v2/auth/token"
+	"github.com/micro/go-micro/v2/metadata"
+)
+
+// metadataAuthorization carries the bearer token of a client
+const metadataAuthorization = "authorization"
+
+// Verifier verifies a bearer token, and returns the account it is issued to. The ID of the account is the user_id.
+type Verifier interface {
+	Verify(token string) (*auth.Account, error)
+}
+
+// TokenVerifier verifies tokens by a go-micro token provider, e.g. JWT. The user_id is the subject of the token.
+type TokenVerifier struct {
+	Provider token.Provider
+}
+
+// Verify implements Verifier
+func (v TokenVerifier) Verify(token string) (*auth.Account, error) {
+	return v.Provider.Inspect(token)
+}
+
+// authenticate returns the account authenticated by the bearer token of the request.
+// It is nil if authentication is disabled.
+func authenticate(ctx context.Context) (*auth.Account, error) {
+	if gVerifier == nil {
+		return nil, nil
+	}
+	if _, ok := metadata.Get(ctx, metadataAuthorization); !ok {
+		return nil, errorUnauthenticated("missing bearer token")
+	}
+	return caller(ctx)
+}
+
+// caller returns the account of the caller by its bearer token. It is nil for anonymous callers,
+// or if authentication is disabled. Scheduled deliveries are published as their original caller.
+func caller(ctx context.Context) (*auth.Account, error) {
+	if o, ok := originOf(ctx); ok {
+		return o.account, nil
+	}
+	if o, ok := forwardedOrigin(ctx); ok {
+		return o.account, nil
+	}
+	if gVerifier == nil {
+		return nil, nil
+	}
+	header, ok := metadata.Get(ctx, metadataAuthorization)
+	if !ok {
+		return nil, nil
+	}
+	if !strings.HasPrefix(header, auth.BearerScheme) {
+		return nil, errorUnauthenticated("invalid authorization, expect %q", auth.BearerScheme)
+	}
+	account, err := gVerifier.Verify(strings.TrimPrefix(header, auth.BearerScheme))
+	if err != nil {
+		return nil, errorUnauthenticated("invalid bearer token: %v", err)
+	}
+	if account.ID == "" {
+		return nil, errorUnauthenticated("bearer token has no subject")
+	}
+	return account, nil
+}
+
+// hasScope tells if account has any of the scopes
+func hasScope(account *auth.Account, scopes ...string) bool {
+	for _, have := range account.Scopes {
+		for _, scope := range scopes {
+			if have == scope {
+				return true
+			}
+		}
+	}
+	return false
+}
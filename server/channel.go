@@ -1,6 +1,8 @@
 package main
 
 import (
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/aclisp/sims/proto"
@@ -10,7 +12,177 @@ import (
 // Channel TODO
 type Channel struct {
 	EventQueue    chan *proto.Event
+	Urgent        chan *proto.Event // high priority events
+	UserAgent     string
 	Birth         time.Time
 	LastHeartbeat time.Time
+	Located       time.Time
 	Active        atomic.Uint32
+	Spilled       atomic.Bool // some events are kept in the mailbox
+	Seq           atomic.Uint64
+	Interval      atomic.Int64 // the negotiated ping interval in nanoseconds
+
+	lock      sync.Mutex
+	unacked   []*proto.Event   // events sent but not acknowledged, in sequence order
+	collapsed map[string]int64 // collapse_key -> timestamp of the latest event queued, which supersedes the older
+
+	uid   UniqueID // the channel of
+	shard uint32   // the index of the shard of uid
+
+	queueLock sync.RWMutex
+	closed    atomic.Bool // EventQueue is closed, so no more events can be queued
+	kicked    atomic.Bool // the channel is closed once EVT_KICKED is sent
+}
+
+// offer queues event unless the queue is full or closed. High priority events go to Urgent.
+func (channel *Channel) offer(event *proto.Event) bool {
+	queue := channel.EventQueue
+	if event.Priority == proto.Priority_PRIORITY_HIGH {
+		queue = channel.Urgent
+	}
+	channel.queueLock.RLock()
+	defer channel.queueLock.RUnlock()
+	if channel.closed.Load() {
+		return false
+	}
+	select {
+	case queue <- event:
+		channel.collapse(event)
+		return true
+	default:
+		return false
+	}
+}
+
+// collapse records event as the latest of its collapse_key, which supersedes the older ones queued or kept
+func (channel *Channel) collapse(event *proto.Event) {
+	if event.CollapseKey == "" {
+		return
+	}
+	channel.lock.Lock()
+	defer channel.lock.Unlock()
+	if channel.collapsed == nil {
+		channel.collapsed = make(map[string]int64)
+	}
+	if event.Timestamp > channel.collapsed[event.CollapseKey] {
+		channel.collapsed[event.CollapseKey] = event.Timestamp
+	}
+}
+
+// superseded tells if a later event of the same collapse_key is queued or kept, so that event should be dropped
+func (channel *Channel) superseded(event *proto.Event) bool {
+	if event.CollapseKey == "" {
+		return false
+	}
+	channel.lock.Lock()
+	defer channel.lock.Unlock()
+	latest, ok := channel.collapsed[event.CollapseKey]
+	if !ok || event.Timestamp > latest {
+		return false
+	}
+	if event.Timestamp < latest {
+		return true
+	}
+	// the latest is going to be sent, forget it unless an older one might be replayed from the mailbox
+	if !channel.Spilled.Load() {
+		delete(channel.collapsed, event.CollapseKey)
+	}
+	return false
+}
+
+// closeQueue closes EventQueue, which is safe with concurrent offer
+func (channel *Channel) closeQueue() {
+	channel.queueLock.Lock()
+	defer channel.queueLock.Unlock()
+	if !channel.closed.Load() {
+		channel.closed.Store(true)
+		close(channel.Urgent)
+		close(channel.EventQueue)
+	}
+}
+
+// negotiateHeartbeat returns the ping interval for the one wanted by a client
+func negotiateHeartbeat(ms int64) time.Duration {
+	if ms <= 0 {
+		return DefaultHeartbeatInterval
+	}
+	interval := time.Duration(ms) * time.Millisecond
+	if interval < MinHeartbeatInterval {
+		return MinHeartbeatInterval
+	}
+	if interval > MaxHeartbeatInterval {
+		return MaxHeartbeatInterval
+	}
+	return interval
+}
+
+// heartbeatInterval returns how often the server pings on the stream
+func (channel *Channel) heartbeatInterval() time.Duration {
+	return time.Duration(channel.Interval.Load())
+}
+
+// inactivity returns the duration without activity after which the channel is closed
+func (channel *Channel) inactivity() time.Duration {
+	return InactivityHeartbeats * channel.heartbeatInterval()
+}
+
+// sequence returns a copy of event stamped with the next sequence of this channel
+func (channel *Channel) sequence(event *proto.Event) *proto.Event {
+	stamped := *event
+	stamped.Seq = channel.Seq.Inc()
+	return &stamped
+}
+
+// sent records a sequenced event as sent, until it is acknowledged
+func (channel *Channel) sent(event *proto.Event) {
+	channel.lock.Lock()
+	defer channel.lock.Unlock()
+	channel.unacked = append(channel.unacked, event)
+	if n := len(channel.unacked) - MaxUnackedEvents; n > 0 {
+		channel.unacked = channel.unacked[n:]
+	}
+}
+
+// ack drops the sent events up to seq, and returns those whose receipts are requested
+func (channel *Channel) ack(seq uint64) (receipted []*proto.Event) {
+	channel.lock.Lock()
+	defer channel.lock.Unlock()
+	i := 0
+	for i < len(channel.unacked) && channel.unacked[i].Seq <= seq {
+		if channel.unacked[i].ReceiptTo != "" {
+			receipted = append(receipted, channel.unacked[i])
+		}
+		i++
+	}
+	channel.unacked = channel.unacked[i:]
+	return receipted
+}
+
+// resume returns the unacknowledged events from seq, which should be redelivered.
+// The events before seq are acknowledged, and those whose receipts are requested are returned too.
+// Zero drops all, which are not acknowledged.
+func (channel *Channel) resume(seq uint64) (redeliver, acked []*proto.Event) {
+	if seq == 0 {
+		channel.lock.Lock()
+		channel.unacked = nil
+		channel.lock.Unlock()
+		return nil, nil
+	}
+	// the client has seen a channel of this device before, e.g. on another node, so keep its sequence increasing
+	if last := channel.Seq.Load(); seq-1 > last {
+		channel.Seq.CAS(last, seq-1)
+	}
+	acked = channel.ack(seq - 1)
+	channel.lock.Lock()
+	defer channel.lock.Unlock()
+	return append([]*proto.Event(nil), channel.unacked...), acked
+}
+
+// matchSelector tells if the channel is targeted by selector.
+// A channel is matched if its user agent contains selector.user_agent, ignoring case.
+func matchSelector(selector *proto.Selector, channel *Channel) bool {
+	if selector.GetUserAgent() == "" {
+		return true
+	}
+	return strings.Contains(strings.ToLower(channel.UserAgent), strings.ToLower(selector.GetUserAgent()))
 }
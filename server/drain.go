@@ -0,0 +1,85 @@
+package main
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"time"
+
+	"github.com/aclisp/sims/proto"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/logger"
+)
+
+// drainPollInterval is how often draining checks whether all clients have left
+const drainPollInterval = 100 * time.Millisecond
+
+func errorDraining() error {
+	return errors.New(proto.ErrorCode_ERR_DRAINING.String(), "node is draining", 503)
+}
+
+// otherNodes returns the addresses of the other SIMS nodes in the registry
+func otherNodes() []string {
+	services, err := gService.Options().Registry.GetService(MicroServiceName)
+	if err != nil {
+		logger.Errorf("get service %q from registry: %v", MicroServiceName, err)
+		return nil
+	}
+	var addresses []string
+	for _, service := range services {
+		for _, node := range service.Nodes {
+			if node.Address != gAddress {
+				addresses = append(addresses, node.Address)
+			}
+		}
+	}
+	return addresses
+}
+
+// reconnectEvent suggests uid to reconnect to one of targets, after a random backoff within grace
+func reconnectEvent(uid UniqueID, targets []string, grace time.Duration) *proto.Event {
+	reconnect := new(proto.Reconnect)
+	if len(targets) > 0 {
+		h := fnv.New32a()
+		h.Write([]byte(uid.UserID))
+		reconnect.Target = targets[h.Sum32()%uint32(len(targets))]
+	}
+	// spread the reconnections over the first half of the grace period, to avoid a thundering herd
+	if window := int64(grace / 2 / time.Millisecond); window > 0 {
+		reconnect.BackoffMs = rand.Int63n(window)
+	}
+	m := jsonpb.Marshaler{OrigName: true}
+	data, _ := m.MarshalToString(reconnect)
+	return &proto.Event{
+		Type:      proto.EventType_EVT_RECONNECT,
+		Data:      []byte(data),
+		Timestamp: time.Now().UnixNano() / int64(time.Millisecond),
+		Priority:  proto.Priority_PRIORITY_HIGH,
+	}
+}
+
+// drain stops accepting clients, and asks every client to reconnect to other nodes.
+// It waits for the clients to leave, up to grace.
+func (reg *Registrar) drain(grace time.Duration) {
+	reg.draining.Store(true)
+	// so that the API gateway routes the clients to other nodes
+	if server, ok := gService.Server().(interface{ Deregister() error }); ok {
+		if err := server.Deregister(); err != nil {
+			logger.Errorf("deregister error: %v", err)
+		}
+	}
+	targets := otherNodes()
+
+	reg.each(func(uid UniqueID, channel *Channel) {
+		if !channel.offer(reconnectEvent(uid, targets, grace)) {
+			logger.Warnf("[%v] queue is full, reconnect event dropped", uid)
+		}
+	})
+	logger.Infof("draining %v channels in %v", reg.size.Load(), grace)
+
+	for deadline := time.Now().Add(grace); time.Now().Before(deadline); time.Sleep(drainPollInterval) {
+		if reg.size.Load() == 0 {
+			break
+		}
+	}
+}
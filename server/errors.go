@@ -12,3 +12,29 @@ func errorNotRegistered(uid UniqueID) error {
 func errorNoConsumer(uid UniqueID) error {
 	return errors.InternalServerError(proto.ErrorCode_ERR_NO_CONSUMER.String(), "no consumer for %v", uid)
 }
+
+func errorUnauthenticated(format string, a ...interface{}) error {
+	return errors.Unauthorized(proto.ErrorCode_ERR_UNAUTHENTICATED.String(), format, a...)
+}
+
+func errorInternal(format string, a ...interface{}) error {
+	return errors.InternalServerError(proto.ErrorCode_ERR_UNSPECIFIED.String(), format, a...)
+}
+
+func validateTopic(topic string) error {
+	if topic == "" {
+		return errors.BadRequest(proto.ErrorCode_ERR_MISSING_TOPIC.String(), "empty topic")
+	}
+	return nil
+}
+
+// errorCode extracts the ErrorCode from errors created by this package
+func errorCode(err error) proto.ErrorCode {
+	if err == nil {
+		return proto.ErrorCode_ERR_UNSPECIFIED
+	}
+	if ierr, ok := err.(*errors.Error); ok {
+		return proto.ErrorCode(proto.ErrorCode_value[ierr.Id])
+	}
+	return proto.ErrorCode_ERR_UNSPECIFIED
+}
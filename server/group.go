@@ -0,0 +1,289 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aclisp/sims/proto"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/store"
+)
+
+const (
+	groupPrefix      = "group"
+	memberPrefix     = "member"
+	membershipPrefix = "membership"
+)
+
+// Groups keeps the membership of groups in the store. Broadcasting to a group is multicasting to its members.
+type Groups struct {
+	store     store.Store
+	publisher *Publisher
+}
+
+// NewGroups creates the group service backed by s, which publishes by pub
+func NewGroups(s store.Store, pub *Publisher) *Groups {
+	return &Groups{
+		store:     s,
+		publisher: pub,
+	}
+}
+
+func (g *Groups) key(groupID string) string {
+	return storeKey(groupPrefix, groupID)
+}
+
+func (g *Groups) memberPrefix(groupID string) string {
+	return storeKey(memberPrefix, groupID) + "/"
+}
+
+func (g *Groups) memberKey(groupID, userID string) string {
+	return storeKey(memberPrefix, groupID, userID)
+}
+
+func (g *Groups) membershipPrefix(userID string) string {
+	return storeKey(membershipPrefix, userID) + "/"
+}
+
+func (g *Groups) membershipKey(userID, groupID string) string {
+	return storeKey(membershipPrefix, userID, groupID)
+}
+
+func errorGroupNotFound(groupID string) error {
+	return errors.NotFound(proto.ErrorCode_ERR_NOT_FOUND.String(), "group %q not found", groupID)
+}
+
+func validateGroupID(groupID string) error {
+	if groupID == "" {
+		return errors.BadRequest(proto.ErrorCode_ERR_MISSING_GROUPID.String(), "empty group_id")
+	}
+	return nil
+}
+
+func validateUserID(userID string) error {
+	if userID == "" {
+		return errors.BadRequest(proto.ErrorCode_ERR_MISSING_USERID.String(), "empty user_id")
+	}
+	return nil
+}
+
+// allowMembership tells if the caller may add or remove a user of a group: itself, or anyone for the accounts
+// of the service or admin scope. Sharing a group may grant publishing to the other members by the policy.
+func allowMembership(ctx context.Context, userIDs ...string) error {
+	account, err := authenticate(ctx)
+	if err != nil || account == nil {
+		// authentication is disabled if both are nil
+		return err
+	}
+	if hasScope(account, serviceScope, adminScope) {
+		return nil
+	}
+	for _, u := range userIDs {
+		if u != account.ID {
+			return errorPermissionDenied("%v may not change the membership of %v", account.ID, u)
+		}
+	}
+	return nil
+}
+
+// exists tells if the group is created
+func (g *Groups) exists(groupID string) (bool, error) {
+	_, err := g.store.Read(g.key(groupID))
+	if err == store.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.InternalServerError(proto.ErrorCode_ERR_UNSPECIFIED.String(), "read group %q: %v", groupID, err)
+	}
+	return true, nil
+}
+
+func (g *Groups) join(groupID, userID string) error {
+	if err := g.store.Write(&store.Record{Key: g.memberKey(groupID, userID)}); err != nil {
+		return errors.InternalServerError(proto.ErrorCode_ERR_UNSPECIFIED.String(), "write member %q of group %q: %v", userID, groupID, err)
+	}
+	if err := g.store.Write(&store.Record{Key: g.membershipKey(userID, groupID)}); err != nil {
+		return errors.InternalServerError(proto.ErrorCode_ERR_UNSPECIFIED.String(), "write membership of %q in group %q: %v", userID, groupID, err)
+	}
+	return nil
+}
+
+// isMember tells if a user is a member of a group
+func (g *Groups) isMember(groupID, userID string) (bool, error) {
+	_, err := g.store.Read(g.memberKey(groupID, userID))
+	if err == store.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.InternalServerError(proto.ErrorCode_ERR_UNSPECIFIED.String(), "read member %q of group %q: %v", userID, groupID, err)
+	}
+	return true, nil
+}
+
+// groupsOf returns the group_id of the groups a user is a member of
+func (g *Groups) groupsOf(userID string) ([]string, error) {
+	prefix := g.membershipPrefix(userID)
+	records, err := g.store.Read(prefix, store.ReadPrefix())
+	if err != nil && err != store.ErrNotFound {
+		return nil, err
+	}
+	groups := make([]string, 0, len(records))
+	for _, record := range records {
+		groups = append(groups, storeKeyPart(strings.TrimPrefix(record.Key, prefix)))
+	}
+	return groups, nil
+}
+
+// members returns the user_id of the members of a group, in order
+func (g *Groups) members(groupID string) ([]string, error) {
+	prefix := g.memberPrefix(groupID)
+	records, err := g.store.Read(prefix, store.ReadPrefix())
+	if err != nil && err != store.ErrNotFound {
+		return nil, errors.InternalServerError(proto.ErrorCode_ERR_UNSPECIFIED.String(), "read members of group %q: %v", groupID, err)
+	}
+	users := make([]string, 0, len(records))
+	for _, record := range records {
+		users = append(users, storeKeyPart(strings.TrimPrefix(record.Key, prefix)))
+	}
+	sort.Strings(users)
+	return users, nil
+}
+
+// Create TODO
+func (g *Groups) Create(ctx context.Context, req *proto.CreateGroupRequest, res *proto.CreateGroupResponse) error {
+	if err := validateGroupID(req.GroupId); err != nil {
+		return err
+	}
+	for _, u := range req.UserId {
+		if err := validateUserID(u); err != nil {
+			return err
+		}
+	}
+	if err := allowMembership(ctx, req.UserId...); err != nil {
+		return err
+	}
+	ok, err := g.exists(req.GroupId)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return errors.Conflict(proto.ErrorCode_ERR_ALREADY_EXISTS.String(), "group %q already exists", req.GroupId)
+	}
+	if err := g.store.Write(&store.Record{
+		Key:   g.key(req.GroupId),
+		Value: []byte(time.Now().Format(time.RFC3339)),
+	}); err != nil {
+		return errors.InternalServerError(proto.ErrorCode_ERR_UNSPECIFIED.String(), "write group %q: %v", req.GroupId, err)
+	}
+	for _, u := range req.UserId {
+		if err := g.join(req.GroupId, u); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Join TODO
+func (g *Groups) Join(ctx context.Context, req *proto.JoinGroupRequest, res *proto.JoinGroupResponse) error {
+	if err := validateGroupID(req.GroupId); err != nil {
+		return err
+	}
+	if err := validateUserID(req.UserId); err != nil {
+		return err
+	}
+	if err := allowMembership(ctx, req.UserId); err != nil {
+		return err
+	}
+	ok, err := g.exists(req.GroupId)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errorGroupNotFound(req.GroupId)
+	}
+	return g.join(req.GroupId, req.UserId)
+}
+
+// Leave TODO
+func (g *Groups) Leave(ctx context.Context, req *proto.LeaveGroupRequest, res *proto.LeaveGroupResponse) error {
+	if err := validateGroupID(req.GroupId); err != nil {
+		return err
+	}
+	if err := validateUserID(req.UserId); err != nil {
+		return err
+	}
+	if err := allowMembership(ctx, req.UserId); err != nil {
+		return err
+	}
+	if err := g.store.Delete(g.memberKey(req.GroupId, req.UserId)); err != nil {
+		return errors.InternalServerError(proto.ErrorCode_ERR_UNSPECIFIED.String(), "delete member %q of group %q: %v", req.UserId, req.GroupId, err)
+	}
+	if err := g.store.Delete(g.membershipKey(req.UserId, req.GroupId)); err != nil {
+		return errors.InternalServerError(proto.ErrorCode_ERR_UNSPECIFIED.String(), "delete membership of %q in group %q: %v", req.UserId, req.GroupId, err)
+	}
+	return nil
+}
+
+// Members TODO
+func (g *Groups) Members(ctx context.Context, req *proto.MembersRequest, res *proto.MembersResponse) error {
+	if err := validateGroupID(req.GroupId); err != nil {
+		return err
+	}
+	ok, err := g.exists(req.GroupId)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errorGroupNotFound(req.GroupId)
+	}
+	res.UserId, err = g.members(req.GroupId)
+	return err
+}
+
+// deniedAll tells if publishing is denied to every user by the policy
+func deniedAll(users []string, codes map[string]proto.ErrorCode) bool {
+	for _, u := range users {
+		if codes[u] != proto.ErrorCode_ERR_PERMISSION_DENIED {
+			return false
+		}
+	}
+	return true
+}
+
+// Broadcast TODO
+func (g *Groups) Broadcast(ctx context.Context, req *proto.BroadcastRequest, res *proto.BroadcastResponse) error {
+	if err := validateGroupID(req.GroupId); err != nil {
+		return err
+	}
+	users, err := g.members(req.GroupId)
+	if err != nil {
+		return err
+	}
+	if len(users) == 0 {
+		ok, err := g.exists(req.GroupId)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errorGroupNotFound(req.GroupId)
+		}
+		res.UserErrcode = make(map[string]proto.ErrorCode)
+		return nil
+	}
+	multicast := new(proto.MulticastResponse)
+	if err := g.publisher.multicast(ctx, &proto.MulticastRequest{
+		UserId:       users,
+		Event:        req.Event,
+		UserSelector: req.UserSelector,
+	}, multicast, false); err != nil {
+		return err
+	}
+	// the event stamped by Multicast is kept, unless it is invalid or denied to every member
+	if validateEvent(UniqueID{}, req.Event) == nil && !deniedAll(users, multicast.UserErrcode) {
+		gHistory.record(groupConversation(req.GroupId), req.Event)
+	}
+	res.UserErrcode = multicast.UserErrcode
+	return nil
+}
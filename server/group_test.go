@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aclisp/sims/proto"
+	"github.com/micro/go-micro/v2/auth"
+	"github.com/micro/go-micro/v2/metadata"
+	"github.com/micro/go-micro/v2/store/memory"
+)
+
+// testVerifier verifies a token of the user_id, or of "service" for the service scope
+type testVerifier struct{}
+
+func (testVerifier) Verify(token string) (*auth.Account, error) {
+	if token == serviceScope {
+		return &auth.Account{ID: "backend", Scopes: []string{serviceScope}}, nil
+	}
+	return &auth.Account{ID: token}, nil
+}
+
+func withToken(token string) context.Context {
+	return metadata.Set(context.Background(), metadataAuthorization, auth.BearerScheme+token)
+}
+
+func TestGroupMembership(t *testing.T) {
+	gVerifier = testVerifier{}
+	defer func() { gVerifier = nil }()
+	g := NewGroups(memory.NewStore(), nil)
+
+	// a user may not put others in a group to share it with them
+	err := g.Create(withToken("a"), &proto.CreateGroupRequest{GroupId: "g", UserId: []string{"a", "b"}}, new(proto.CreateGroupResponse))
+	if errorCode(err) != proto.ErrorCode_ERR_PERMISSION_DENIED {
+		t.Fatalf("create a group with another user: %v, want %v", err, proto.ErrorCode_ERR_PERMISSION_DENIED)
+	}
+	if err := g.Create(withToken("a"), &proto.CreateGroupRequest{GroupId: "g", UserId: []string{"a"}}, new(proto.CreateGroupResponse)); err != nil {
+		t.Fatal(err)
+	}
+	err = g.Join(withToken("a"), &proto.JoinGroupRequest{GroupId: "g", UserId: "b"}, new(proto.JoinGroupResponse))
+	if errorCode(err) != proto.ErrorCode_ERR_PERMISSION_DENIED {
+		t.Fatalf("join another user: %v, want %v", err, proto.ErrorCode_ERR_PERMISSION_DENIED)
+	}
+	err = g.Join(context.Background(), &proto.JoinGroupRequest{GroupId: "g", UserId: "b"}, new(proto.JoinGroupResponse))
+	if errorCode(err) != proto.ErrorCode_ERR_UNAUTHENTICATED {
+		t.Fatalf("join anonymously: %v, want %v", err, proto.ErrorCode_ERR_UNAUTHENTICATED)
+	}
+	if err := g.Join(withToken("b"), &proto.JoinGroupRequest{GroupId: "g", UserId: "b"}, new(proto.JoinGroupResponse)); err != nil {
+		t.Fatal(err)
+	}
+	err = g.Leave(withToken("a"), &proto.LeaveGroupRequest{GroupId: "g", UserId: "b"}, new(proto.LeaveGroupResponse))
+	if errorCode(err) != proto.ErrorCode_ERR_PERMISSION_DENIED {
+		t.Fatalf("remove another user: %v, want %v", err, proto.ErrorCode_ERR_PERMISSION_DENIED)
+	}
+	if err := g.Join(withToken(serviceScope), &proto.JoinGroupRequest{GroupId: "g", UserId: "c"}, new(proto.JoinGroupResponse)); err != nil {
+		t.Fatal(err)
+	}
+
+	members := new(proto.MembersResponse)
+	if err := g.Members(context.Background(), &proto.MembersRequest{GroupId: "g"}, members); err != nil {
+		t.Fatal(err)
+	}
+	if len(members.UserId) != 3 {
+		t.Fatalf("members %v, want a, b and c", members.UserId)
+	}
+}
@@ -0,0 +1,256 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aclisp/sims/proto"
+	pb "github.com/golang/protobuf/proto"
+	"github.com/micro/go-micro/v2/auth"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/logger"
+	"github.com/micro/go-micro/v2/store"
+	"go.uber.org/atomic"
+)
+
+const (
+	historyPrefix = "history"
+	// DefaultHistoryFetch is the number of events fetched if the limit is not given
+	DefaultHistoryFetch = 50
+	// MaxHistoryFetch is the maximum number of events fetched at once
+	MaxHistoryFetch = 500
+	// serviceScope is the scope of the accounts of backend services, which may fetch any conversation
+	serviceScope = "service"
+	// historyTrimSlack is the fraction of its limit a conversation may exceed before trimmed,
+	// so that it is not listed on every event recorded
+	historyTrimSlack = 10
+)
+
+// ParseHistoryLimits parses the limits of the conversations of some users, as comma separated user_id=limit
+func ParseHistoryLimits(s string) (map[string]int, error) {
+	limits := make(map[string]int)
+	for _, p := range strings.Split(s, ",") {
+		parts := strings.SplitN(strings.TrimSpace(p), "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid user limit %q, expect user_id=limit", p)
+		}
+		limit, err := strconv.Atoi(parts[1])
+		if err != nil || limit < 0 {
+			return nil, fmt.Errorf("invalid limit %q of %v", parts[1], parts[0])
+		}
+		limits[parts[0]] = limit
+	}
+	return limits, nil
+}
+
+// History keeps the events of each conversation in the store, for up to ttl and the latest limit events.
+// The conversation of a user in userLimits keeps its own limit instead.
+type History struct {
+	store      store.Store
+	groups     *Groups
+	ttl        time.Duration
+	limit      int
+	userLimits map[string]int
+	seq        atomic.Uint64
+
+	lock sync.Mutex
+	// untrimmed counts the events recorded in each conversation since it was trimmed by this node
+	untrimmed map[string]int
+}
+
+// NewHistory creates the history backed by s, which keeps limit events of a conversation for ttl,
+// or the limit in userLimits of the conversation of a user. The members of a group may fetch its conversation.
+func NewHistory(s store.Store, groups *Groups, ttl time.Duration, limit int, userLimits map[string]int) *History {
+	return &History{
+		store:      s,
+		groups:     groups,
+		ttl:        ttl,
+		limit:      limit,
+		userLimits: userLimits,
+		untrimmed:  make(map[string]int),
+	}
+}
+
+func userConversation(userID string) string {
+	return "user/" + userID
+}
+
+func groupConversation(groupID string) string {
+	return "group/" + groupID
+}
+
+// limitOf returns the number of the latest events kept in a conversation, which is unlimited if zero
+func (h *History) limitOf(conversation string) int {
+	if strings.HasPrefix(conversation, userConversation("")) {
+		if limit, ok := h.userLimits[strings.TrimPrefix(conversation, userConversation(""))]; ok {
+			return limit
+		}
+	}
+	return h.limit
+}
+
+// prefix returns the key prefix of the events of a conversation
+func (h *History) prefix(conversation string) string {
+	return storeKey(historyPrefix, conversation) + "/"
+}
+
+// record keeps event in a conversation, and drops the oldest beyond the limit once in a while
+func (h *History) record(conversation string, event *proto.Event) {
+	if h == nil {
+		return
+	}
+	kept := *event
+	kept.Seq = 0
+	kept.ReceiptTo = ""
+	value, err := pb.Marshal(&kept)
+	if err != nil {
+		logger.Errorf("[%v] encode history error: %v", conversation, err)
+		return
+	}
+	prefix := h.prefix(conversation)
+	if err := h.store.Write(&store.Record{
+		Key:    fmt.Sprintf("%s%020d-%010d", prefix, time.Now().UnixNano(), h.seq.Inc()),
+		Value:  value,
+		Expiry: h.ttl,
+	}); err != nil {
+		logger.Errorf("[%v] write history error: %v", conversation, err)
+		return
+	}
+	if limit := h.limitOf(conversation); h.trimDue(conversation, limit) {
+		if err := h.trim(prefix, limit); err != nil {
+			logger.Errorf("[%v] trim history error: %v", conversation, err)
+		}
+	}
+}
+
+// trimDue counts an event recorded in a conversation, and tells if the conversation should be trimmed:
+// once it may exceed limit by its slack. Fetch skips the events beyond the limit meanwhile.
+func (h *History) trimDue(conversation string, limit int) bool {
+	if limit <= 0 {
+		return false
+	}
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.untrimmed[conversation]++
+	if h.untrimmed[conversation] <= limit/historyTrimSlack {
+		return false
+	}
+	delete(h.untrimmed, conversation)
+	return true
+}
+
+// trim deletes the oldest events under prefix beyond limit
+func (h *History) trim(prefix string, limit int) error {
+	if limit <= 0 {
+		return nil
+	}
+	keys, err := h.store.List(store.ListPrefix(prefix))
+	if err != nil || len(keys) <= limit {
+		return err
+	}
+	sort.Strings(keys)
+	for _, key := range keys[:len(keys)-limit] {
+		if err := h.store.Delete(key); err != nil && err != store.ErrNotFound {
+			return err
+		}
+	}
+	return nil
+}
+
+// allowFetch tells if the caller may fetch a conversation: its own, or of a group it is a member of
+func (h *History) allowFetch(account *auth.Account, conversation string) error {
+	if account == nil {
+		// authentication is disabled
+		return nil
+	}
+	if hasScope(account, serviceScope) {
+		return nil
+	}
+	if conversation == userConversation(account.ID) {
+		return nil
+	}
+	if strings.HasPrefix(conversation, groupConversation("")) {
+		groupID := strings.TrimPrefix(conversation, groupConversation(""))
+		ok, err := h.groups.isMember(groupID, account.ID)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+	}
+	return errorPermissionDenied("%v may not fetch %v", account.ID, conversation)
+}
+
+// Fetch TODO
+func (h *History) Fetch(ctx context.Context, req *proto.FetchRequest, res *proto.FetchResponse) error {
+	if !strings.HasPrefix(req.Conversation, userConversation("")) && !strings.HasPrefix(req.Conversation, groupConversation("")) {
+		return errors.BadRequest(proto.ErrorCode_ERR_INVALID_CONVERSATION.String(), "conversation should be user/<user_id> or group/<group_id>, not %q", req.Conversation)
+	}
+	account, err := authenticate(ctx)
+	if err != nil {
+		return err
+	}
+	if err := h.allowFetch(account, req.Conversation); err != nil {
+		return err
+	}
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = DefaultHistoryFetch
+	}
+	if limit > MaxHistoryFetch {
+		limit = MaxHistoryFetch
+	}
+
+	prefix := h.prefix(req.Conversation)
+	keys, err := h.store.List(store.ListPrefix(prefix))
+	if err != nil && err != store.ErrNotFound {
+		return errorInternal("list history of %v: %v", req.Conversation, err)
+	}
+	sort.Strings(keys)
+	// the events beyond the limit are only trimmed once in a while
+	trimmed := 0
+	if kept := h.limitOf(req.Conversation); kept > 0 && len(keys) > kept {
+		trimmed = len(keys) - kept
+		keys = keys[trimmed:]
+	}
+	end := len(keys)
+	if req.Before != "" {
+		end = sort.SearchStrings(keys, prefix+req.Before)
+	}
+	start := end - limit
+	if start < 0 {
+		start = 0
+	}
+	if start == end {
+		return nil
+	}
+	records, err := h.store.Read(prefix, store.ReadPrefix(), store.ReadOffset(uint(trimmed+start)), store.ReadLimit(uint(end-start)))
+	if err != nil && err != store.ErrNotFound {
+		return errorInternal("read history of %v: %v", req.Conversation, err)
+	}
+
+	for _, record := range records {
+		// the events expired or trimmed since listing shift the page
+		if req.Before != "" && record.Key >= prefix+req.Before {
+			continue
+		}
+		event := new(proto.Event)
+		if err := pb.Unmarshal(record.Value, event); err != nil {
+			continue
+		}
+		res.Event = append(res.Event, event)
+		if res.Before == "" {
+			res.Before = strings.TrimPrefix(record.Key, prefix)
+		}
+	}
+	if start == 0 {
+		res.Before = ""
+	}
+	return nil
+}
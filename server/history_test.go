@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/aclisp/sims/proto"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/go-micro/v2/store/memory"
+)
+
+func TestHistoryFetch(t *testing.T) {
+	s := memory.NewStore()
+	h := NewHistory(s, NewGroups(s, nil), time.Hour, 5, nil)
+	for i := 0; i < 7; i++ {
+		h.record(userConversation("a"), &proto.Event{Type: proto.EventType_EVT_TEXT, Data: []byte(strconv.Itoa(i))})
+	}
+	h.record(userConversation("b"), &proto.Event{Type: proto.EventType_EVT_TEXT, Data: []byte("b")})
+
+	// the latest 5 are kept, fetched backwards by pages of 2
+	var pages [][]string
+	req := &proto.FetchRequest{Conversation: userConversation("a"), Limit: 2}
+	for {
+		res := new(proto.FetchResponse)
+		if err := h.Fetch(context.Background(), req, res); err != nil {
+			t.Fatal(err)
+		}
+		var page []string
+		for _, event := range res.Event {
+			page = append(page, string(event.Data))
+		}
+		pages = append(pages, page)
+		if res.Before == "" {
+			break
+		}
+		req.Before = res.Before
+	}
+	want := [][]string{{"5", "6"}, {"3", "4"}, {"2"}}
+	if len(pages) != len(want) {
+		t.Fatalf("fetched %v, want %v", pages, want)
+	}
+	for i := range want {
+		if len(pages[i]) != len(want[i]) || pages[i][0] != want[i][0] || pages[i][len(pages[i])-1] != want[i][len(want[i])-1] {
+			t.Fatalf("fetched %v, want %v", pages, want)
+		}
+	}
+
+	err := h.Fetch(context.Background(), &proto.FetchRequest{Conversation: "a"}, new(proto.FetchResponse))
+	if errorCode(err) != proto.ErrorCode_ERR_INVALID_CONVERSATION {
+		t.Fatalf("fetch an invalid conversation: %v, want %v", err, proto.ErrorCode_ERR_INVALID_CONVERSATION)
+	}
+}
+
+func TestHistoryUserLimits(t *testing.T) {
+	uids := newTestRegistrar(3)
+	limits, err := ParseHistoryLimits(uids[0].UserID + "=3, " + uids[1].UserID + "=0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseHistoryLimits("bot"); err == nil {
+		t.Fatal("parsed a limit without user_id=")
+	}
+	s := memory.NewStore()
+	gHistory = NewHistory(s, NewGroups(s, nil), time.Hour, 2, limits)
+	defer func() { gHistory = nil }()
+
+	// multicast events are kept in the conversation of each recipient, up to the limit of the user
+	for i := 0; i < 5; i++ {
+		req := &proto.MulticastRequest{
+			UserId: []string{uids[0].UserID, uids[1].UserID, uids[2].UserID},
+			Event:  &proto.Event{Type: proto.EventType_EVT_TEXT, Data: []byte(strconv.Itoa(i))},
+		}
+		if err := new(Publisher).Multicast(context.Background(), req, new(proto.MulticastResponse)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i, want := range []int{3, 5, 2} {
+		res := new(proto.FetchResponse)
+		if err := gHistory.Fetch(context.Background(), &proto.FetchRequest{Conversation: userConversation(uids[i].UserID)}, res); err != nil {
+			t.Fatal(err)
+		}
+		if len(res.Event) != want {
+			t.Errorf("%v kept %v events, want %v", uids[i].UserID, len(res.Event), want)
+		}
+	}
+}
+
+func TestHistoryBroadcast(t *testing.T) {
+	uids := newTestRegistrar(2)
+	s := memory.NewStore()
+	groups := NewGroups(s, new(Publisher))
+	gHistory = NewHistory(s, groups, time.Hour, 20, nil)
+	defer func() { gHistory = nil }()
+
+	if err := groups.Create(context.Background(), &proto.CreateGroupRequest{GroupId: "g", UserId: []string{uids[0].UserID, uids[1].UserID}}, new(proto.CreateGroupResponse)); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 25; i++ {
+		req := &proto.BroadcastRequest{GroupId: "g", Event: &proto.Event{Type: proto.EventType_EVT_TEXT, Data: []byte(strconv.Itoa(i))}}
+		if err := groups.Broadcast(context.Background(), req, new(proto.BroadcastResponse)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the group events are only kept in the conversation of the group
+	if keys, _ := s.List(store.ListPrefix(gHistory.prefix(userConversation(uids[0].UserID)))); len(keys) != 0 {
+		t.Fatalf("%v group events kept for a member, want 0", len(keys))
+	}
+	// which is trimmed once in a while, but fetched up to the limit
+	keys, _ := s.List(store.ListPrefix(gHistory.prefix(groupConversation("g"))))
+	if len(keys) < 20 || len(keys) > 20+20/historyTrimSlack {
+		t.Fatalf("%v group events kept, want 20 and the slack", len(keys))
+	}
+	res := new(proto.FetchResponse)
+	if err := gHistory.Fetch(context.Background(), &proto.FetchRequest{Conversation: groupConversation("g"), Limit: 50}, res); err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Event) != 20 || string(res.Event[0].Data) != "5" {
+		t.Fatalf("fetched %v events from %q, want 20 from \"5\"", len(res.Event), res.Event[0].Data)
+	}
+}
@@ -0,0 +1,313 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aclisp/sims/proto"
+	"github.com/golang/protobuf/jsonpb"
+	pb "github.com/golang/protobuf/proto"
+	"github.com/google/uuid"
+	"github.com/micro/go-micro/v2/broker"
+	"github.com/micro/go-micro/v2/logger"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/go-micro/v2/util/backoff"
+)
+
+const (
+	deadLetterPrefix = "deadletter"
+	// signatureHeader carries the HMAC-SHA256 of the body by the secret of a hook, as sha256=<hex>
+	signatureHeader = "X-Sims-Signature"
+	// HookQueueSize is the number of notifications buffered for each hook
+	HookQueueSize = 1024
+	// MaxHookAttempts is the number of attempts to post a notification, before it is kept as a dead letter
+	MaxHookAttempts = 5
+	// HookTimeout is the timeout of posting a notification to an HTTP hook
+	HookTimeout = 5 * time.Second
+	// DeadLetterTTL is how long the dead letters are kept in the store
+	DeadLetterTTL = 7 * 24 * time.Hour
+	// DefaultDeadLetters is the number of dead letters returned if the limit is not given
+	DefaultDeadLetters = 50
+	// MaxDeadLetters is the maximum number of dead letters returned at once
+	MaxDeadLetters = 500
+)
+
+// HookConfig is the configuration of a hook, which is posted the notifications by HTTP or a broker topic
+type HookConfig struct {
+	URL    string   `json:"url"`    // HTTP endpoint, or
+	Topic  string   `json:"topic"`  // broker topic
+	Events []string `json:"events"` // HookEvent names, or every event if empty
+	Secret string   `json:"secret"` // key signing the body, if any
+}
+
+// LoadHooks reads the hooks from a JSON file of HookConfig array
+func LoadHooks(path string) ([]HookConfig, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var configs []HookConfig
+	if err := json.Unmarshal(data, &configs); err != nil {
+		return nil, err
+	}
+	for i, c := range configs {
+		if (c.URL == "") == (c.Topic == "") {
+			return nil, fmt.Errorf("hook %v: need either url or topic", i)
+		}
+		for _, name := range c.Events {
+			if _, ok := proto.HookEvent_value[name]; !ok {
+				return nil, fmt.Errorf("hook %v: invalid event %q", i, name)
+			}
+		}
+	}
+	return configs, nil
+}
+
+type hook struct {
+	HookConfig
+	events map[proto.HookEvent]bool
+	queue  chan *proto.Notification
+}
+
+// name identifies a hook in logs and dead letters
+func (k *hook) name() string {
+	if k.URL != "" {
+		return k.URL
+	}
+	return k.Topic
+}
+
+// Hooks posts the notifications of the lifecycle of channels and the delivery failures to the hooks.
+// Each hook is posted in order by its own worker, and the notifications failed after retries are kept as dead letters.
+type Hooks struct {
+	hooks  []*hook
+	client *http.Client
+	broker broker.Broker
+	store  store.Store
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewHooks creates the hooks of configs, publishing by b, and keeping the dead letters in s
+func NewHooks(configs []HookConfig, b broker.Broker, s store.Store) *Hooks {
+	h := &Hooks{
+		client: &http.Client{Timeout: HookTimeout},
+		broker: b,
+		store:  s,
+		stop:   make(chan struct{}),
+	}
+	for _, c := range configs {
+		k := &hook{
+			HookConfig: c,
+			events:     make(map[proto.HookEvent]bool),
+			queue:      make(chan *proto.Notification, HookQueueSize),
+		}
+		for _, name := range c.Events {
+			k.events[proto.HookEvent(proto.HookEvent_value[name])] = true
+		}
+		h.hooks = append(h.hooks, k)
+	}
+	return h
+}
+
+// Start runs the worker of each hook
+func (h *Hooks) Start() {
+	for _, k := range h.hooks {
+		h.wg.Add(1)
+		go h.run(k)
+	}
+}
+
+// Stop keeps the pending notifications as dead letters, and waits for the workers to exit
+func (h *Hooks) Stop() {
+	if h == nil {
+		return
+	}
+	close(h.stop)
+	h.wg.Wait()
+}
+
+// notify posts a notification of uid to the hooks interested in event
+func (h *Hooks) notify(event proto.HookEvent, uid UniqueID, eventID, reason string) {
+	if h == nil {
+		return
+	}
+	n := &proto.Notification{
+		Id:        uuid.New().String(),
+		Event:     event,
+		UserId:    uid.UserID,
+		DeviceId:  uid.DeviceID,
+		Timestamp: time.Now().UnixNano() / int64(time.Millisecond),
+		Node:      gAddress,
+		EventId:   eventID,
+		Reason:    reason,
+	}
+	for _, k := range h.hooks {
+		if len(k.events) > 0 && !k.events[event] {
+			continue
+		}
+		select {
+		case k.queue <- n:
+		default:
+			h.deadLetter(k, n, fmt.Errorf("hook queue is full"), 0)
+		}
+	}
+}
+
+// notifyUndeliverable notifies an event failed with ERR_NO_CONSUMER, unless forwarded, so that it is notified once
+func notifyUndeliverable(ctx context.Context, userID string, event *proto.Event, err error) {
+	if errorCode(err) != proto.ErrorCode_ERR_NO_CONSUMER || isForwarded(ctx) {
+		return
+	}
+	gHooks.notify(proto.HookEvent_HOOK_UNDELIVERABLE, UniqueID{UserID: userID}, event.GetId(), err.Error())
+}
+
+func (h *Hooks) run(k *hook) {
+	defer h.wg.Done()
+	for {
+		select {
+		case <-h.stop:
+			for {
+				select {
+				case n := <-k.queue:
+					h.deadLetter(k, n, fmt.Errorf("node stopped"), 0)
+				default:
+					return
+				}
+			}
+		case n := <-k.queue:
+			h.deliver(k, n)
+		}
+	}
+}
+
+// deliver posts a notification to a hook, retrying with backoff
+func (h *Hooks) deliver(k *hook, n *proto.Notification) {
+	// every field is present, e.g. HOOK_CONNECTED as the zero event
+	m := jsonpb.Marshaler{OrigName: true, EmitDefaults: true}
+	body, err := m.MarshalToString(n)
+	if err != nil {
+		logger.Errorf("[%v] encode notification error: %v", n.UserId, err)
+		return
+	}
+	attempts := 0
+	for {
+		attempts++
+		if err = h.post(k, []byte(body)); err == nil {
+			return
+		}
+		if attempts >= MaxHookAttempts {
+			break
+		}
+		select {
+		case <-h.stop:
+			h.deadLetter(k, n, err, attempts)
+			return
+		case <-time.After(backoff.Do(attempts)):
+		}
+	}
+	h.deadLetter(k, n, err, attempts)
+}
+
+// sign returns the signature of body by secret
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (h *Hooks) post(k *hook, body []byte) error {
+	header := map[string]string{"Content-Type": "application/json"}
+	if k.Secret != "" {
+		header[signatureHeader] = sign(k.Secret, body)
+	}
+	if k.Topic != "" {
+		return h.broker.Publish(k.Topic, &broker.Message{Header: header, Body: body})
+	}
+	req, err := http.NewRequest(http.MethodPost, k.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for key, value := range header {
+		req.Header.Set(key, value)
+	}
+	rsp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+	io.Copy(ioutil.Discard, rsp.Body)
+	if rsp.StatusCode/100 != 2 {
+		return fmt.Errorf("status %v", rsp.Status)
+	}
+	return nil
+}
+
+// deadLetter keeps a notification failed on a hook in the store
+func (h *Hooks) deadLetter(k *hook, n *proto.Notification, err error, attempts int) {
+	logger.Errorf("[%v] notify %v to %v failed after %v attempts: %v", n.UserId, n.Event, k.name(), attempts, err)
+	value, merr := pb.Marshal(&proto.DeadLetter{
+		Notification: n,
+		Hook:         k.name(),
+		Error:        err.Error(),
+		Attempts:     int32(attempts),
+	})
+	if merr != nil {
+		logger.Errorf("[%v] encode dead letter error: %v", n.UserId, merr)
+		return
+	}
+	if werr := h.store.Write(&store.Record{
+		Key:    storeKey(deadLetterPrefix, fmt.Sprintf("%020d-%s", time.Now().UnixNano(), uuid.New().String())),
+		Value:  value,
+		Expiry: DeadLetterTTL,
+	}); werr != nil {
+		logger.Errorf("[%v] write dead letter error: %v", n.UserId, werr)
+	}
+}
+
+// DeadLetters TODO
+func (a *Admin) DeadLetters(ctx context.Context, req *proto.DeadLettersRequest, res *proto.DeadLettersResponse) error {
+	if err := allowAdmin(ctx); err != nil {
+		return err
+	}
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = DefaultDeadLetters
+	}
+	if limit > MaxDeadLetters {
+		limit = MaxDeadLetters
+	}
+	prefix := storeKey(deadLetterPrefix) + "/"
+	keys, err := a.store.List(store.ListPrefix(prefix))
+	if err != nil && err != store.ErrNotFound {
+		return errorInternal("list dead letters: %v", err)
+	}
+	sort.Strings(keys)
+	if len(keys) > limit {
+		keys = keys[len(keys)-limit:]
+	}
+	for _, key := range keys {
+		records, err := a.store.Read(key)
+		if err != nil || len(records) == 0 {
+			continue
+		}
+		letter := new(proto.DeadLetter)
+		if err := pb.Unmarshal(records[0].Value, letter); err != nil {
+			continue
+		}
+		res.DeadLetters = append(res.DeadLetters, letter)
+	}
+	return nil
+}
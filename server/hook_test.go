@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aclisp/sims/proto"
+	"github.com/micro/go-micro/v2/store/memory"
+	"go.uber.org/atomic"
+)
+
+func TestHooks(t *testing.T) {
+	var calls atomic.Int32
+	received := make(chan string, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if r.Header.Get(signatureHeader) != sign("secret", body) {
+			t.Errorf("signature %q mismatched", r.Header.Get(signatureHeader))
+		}
+		// fails the first attempt
+		if calls.Inc() == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		received <- string(body)
+	}))
+	s := memory.NewStore()
+	hooks := NewHooks([]HookConfig{{
+		URL:    srv.URL,
+		Events: []string{proto.HookEvent_HOOK_TIMED_OUT.String()},
+		Secret: "secret",
+	}}, nil, s)
+	hooks.Start()
+
+	uid := UniqueID{UserID: "a", DeviceID: "d"}
+	hooks.notify(proto.HookEvent_HOOK_CONNECTED, uid, "", "")
+	hooks.notify(proto.HookEvent_HOOK_TIMED_OUT, uid, "", "")
+	select {
+	case <-received:
+		if calls.Load() != 2 {
+			t.Fatalf("posted %v times, want 2", calls.Load())
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("notification not received")
+	}
+
+	// the notifications failing when the node stops are kept as dead letters
+	srv.Close()
+	hooks.notify(proto.HookEvent_HOOK_TIMED_OUT, uid, "", "")
+	time.Sleep(100 * time.Millisecond)
+	hooks.Stop()
+	res := new(proto.DeadLettersResponse)
+	if err := NewAdmin(s, nil).DeadLetters(context.Background(), new(proto.DeadLettersRequest), res); err != nil {
+		t.Fatal(err)
+	}
+	if len(res.DeadLetters) != 1 || res.DeadLetters[0].Notification.Event != proto.HookEvent_HOOK_TIMED_OUT || res.DeadLetters[0].Hook != srv.URL {
+		t.Fatalf("dead letters %v, want the timed out notification", res.DeadLetters)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/micro/go-micro/v2/store"
+)
+
+const locationPrefix = "location"
+
+// Locator is the user location directory. It tells which SIMS node each device of a user is connected to.
+type Locator struct {
+	store store.Store
+}
+
+// NewLocator creates a user location directory backed by s
+func NewLocator(s store.Store) *Locator {
+	return &Locator{
+		store: s,
+	}
+}
+
+func (loc *Locator) prefix(userID string) string {
+	return storeKey(locationPrefix, userID) + "/"
+}
+
+func (loc *Locator) key(uid UniqueID) string {
+	return storeKey(locationPrefix, uid.UserID, uid.DeviceID)
+}
+
+// Register records that uid is connected to the node at address
+func (loc *Locator) Register(uid UniqueID, address string) error {
+	return loc.store.Write(&store.Record{
+		Key:    loc.key(uid),
+		Value:  []byte(address),
+		Expiry: LocationTTL,
+	})
+}
+
+// Deregister removes the location of uid, if it is still owned by the node at address
+func (loc *Locator) Deregister(uid UniqueID, address string) error {
+	records, err := loc.store.Read(loc.key(uid))
+	if err == store.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 || string(records[0].Value) != address {
+		return nil
+	}
+	return loc.store.Delete(loc.key(uid))
+}
+
+// Lookup returns the addresses of the nodes each device of a user is connected to, keyed by device_id
+func (loc *Locator) Lookup(userID string) (map[string]string, error) {
+	prefix := loc.prefix(userID)
+	records, err := loc.store.Read(prefix, store.ReadPrefix())
+	if err != nil && err != store.ErrNotFound {
+		return nil, err
+	}
+	devices := make(map[string]string, len(records))
+	for _, record := range records {
+		devices[storeKeyPart(strings.TrimPrefix(record.Key, prefix))] = string(record.Value)
+	}
+	return devices, nil
+}
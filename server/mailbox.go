@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aclisp/sims/proto"
+	pb "github.com/golang/protobuf/proto"
+	"github.com/micro/go-micro/v2/store"
+	"go.uber.org/atomic"
+)
+
+const mailboxPrefix = "mailbox"
+
+// Mailbox keeps the events which could not be delivered, until the user comes back
+type Mailbox struct {
+	store store.Store
+	ttl   time.Duration
+	seq   atomic.Uint64
+}
+
+// NewMailbox creates a mailbox backed by s, whose events expire after ttl
+func NewMailbox(s store.Store, ttl time.Duration) *Mailbox {
+	return &Mailbox{
+		store: s,
+		ttl:   ttl,
+	}
+}
+
+// prefix returns the key prefix of the events to uid. Events to any device of a user have an empty device_id.
+func (mb *Mailbox) prefix(uid UniqueID) string {
+	return storeKey(mailboxPrefix, uid.UserID, uid.DeviceID) + "/"
+}
+
+// Put keeps event for uid. If uid has no device_id, the event goes to the first device matched by selector.
+// The event is kept no longer than its TTL, and replaces the events of the same collapse_key kept for uid.
+func (mb *Mailbox) Put(uid UniqueID, selector *proto.Selector, event *proto.Event) error {
+	expiry := mb.ttl
+	if ttl, ok := expiresIn(event); ok {
+		if ttl <= 0 {
+			return nil
+		}
+		if ttl < expiry {
+			expiry = ttl
+		}
+	}
+	value, err := pb.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if event.CollapseKey != "" {
+		if err := mb.collapse(mb.prefix(uid), event.CollapseKey); err != nil {
+			return err
+		}
+	}
+	defer traceSpan("sims.mailbox", uid, event, time.Now(), nil)
+	return mb.store.Write(&store.Record{
+		Key:   fmt.Sprintf("%s%020d-%010d", mb.prefix(uid), time.Now().UnixNano(), mb.seq.Inc()),
+		Value: value,
+		Metadata: map[string]interface{}{
+			"user_agent":   selector.GetUserAgent(),
+			"collapse_key": event.CollapseKey,
+		},
+		Expiry: expiry,
+	})
+}
+
+// collapse deletes the events of collapseKey kept under prefix
+func (mb *Mailbox) collapse(prefix, collapseKey string) error {
+	records, err := mb.read(prefix)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		if key, _ := record.Metadata["collapse_key"].(string); key != collapseKey {
+			continue
+		}
+		if err := mb.store.Delete(record.Key); err != nil && err != store.ErrNotFound {
+			return err
+		}
+	}
+	return nil
+}
+
+// Take removes and returns the events kept for uid, and for any device of the user matched by channel, in time order
+func (mb *Mailbox) Take(uid UniqueID, channel *Channel) ([]*proto.Event, error) {
+	records, err := mb.read(mb.prefix(uid))
+	if err != nil {
+		return nil, err
+	}
+	if uid.DeviceID != "" {
+		anyDevice, err := mb.read(mb.prefix(UniqueID{UserID: uid.UserID}))
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range anyDevice {
+			userAgent, _ := record.Metadata["user_agent"].(string)
+			if matchSelector(&proto.Selector{UserAgent: userAgent}, channel) {
+				records = append(records, record)
+			}
+		}
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Key[strings.LastIndexByte(records[i].Key, '/'):] < records[j].Key[strings.LastIndexByte(records[j].Key, '/'):]
+	})
+
+	events := make([]*proto.Event, 0, len(records))
+	for _, record := range records {
+		if err := mb.store.Delete(record.Key); err != nil {
+			return events, err
+		}
+		event := new(proto.Event)
+		if err := pb.Unmarshal(record.Value, event); err != nil || expired(event) {
+			continue
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func (mb *Mailbox) read(prefix string) ([]*store.Record, error) {
+	records, err := mb.store.Read(prefix, store.ReadPrefix())
+	if err != nil && err != store.ErrNotFound {
+		return nil, err
+	}
+	// a record might expire between listing and reading, which is harmless
+	return records, nil
+}
@@ -2,53 +2,248 @@ package main
 
 import (
 	"errors"
+	"net"
 	"net/http"
 	_ "net/http/pprof"
+	"strings"
 	"time"
 
+	"github.com/aclisp/sims/pkg/codec"
 	"github.com/aclisp/sims/proto"
 	"github.com/micro/cli/v2"
 	"github.com/micro/go-micro/v2"
+	"github.com/micro/go-micro/v2/auth"
+	"github.com/micro/go-micro/v2/auth/token"
+	"github.com/micro/go-micro/v2/auth/token/jwt"
 	"github.com/micro/go-micro/v2/logger"
 	"github.com/micro/go-micro/v2/registry"
+	grpcserver "github.com/micro/go-micro/v2/server/grpc"
+	"github.com/micro/go-micro/v2/sync"
+	"github.com/micro/go-micro/v2/sync/etcd"
+	"github.com/micro/go-micro/v2/sync/memory"
 	"golang.org/x/net/trace"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
 )
 
 const (
 	// MicroServiceName is the name of the service
 	MicroServiceName = "go.micro.srv.sims"
 	// HousekeepInterval is the duration after which we do housekeeping
-	HousekeepInterval = 5 * time.Second
-	// ChannelInactivity is the duration after which an inactive channel is closed by the server
-	ChannelInactivity = 10 * time.Second
+	HousekeepInterval = time.Second
+	// DefaultHeartbeatInterval is how often the server pings on Streamer.Events, unless negotiated by the client
+	DefaultHeartbeatInterval = 5 * time.Second
+	// MinHeartbeatInterval and MaxHeartbeatInterval bound the ping interval negotiated by the client
+	MinHeartbeatInterval = time.Second
+	MaxHeartbeatInterval = 2 * time.Minute
+	// InactivityHeartbeats is the number of ping intervals without activity after which a channel is closed by the server
+	InactivityHeartbeats = 2
+	// DefaultEventQueueSize is the default number of events buffered for each channel
+	DefaultEventQueueSize = 64
+	// UrgentQueueSize is the number of high priority events buffered for each channel, which jump the queue
+	UrgentQueueSize = 16
+	// MaxUnackedEvents is the maximum number of sent events kept for each channel until acknowledged
+	MaxUnackedEvents = 1024
+	// DefaultHistoryLimit is the default number of the latest events kept for each conversation
+	DefaultHistoryLimit = 1000
+	// DefaultDrainGrace is the default duration for the clients to reconnect to other nodes, when the node shuts down
+	DefaultDrainGrace = 5 * time.Second
+	// KeepaliveTime is how often the gRPC transport pings an idle client connection
+	KeepaliveTime = 30 * time.Second
+	// KeepaliveTimeout is the duration after which a client connection is closed if a ping is unanswered
+	KeepaliveTimeout = 10 * time.Second
+	// LocationTTL is the duration after which a user location is expired from the store, unless refreshed by heartbeats
+	LocationTTL = time.Minute
 )
 
 var (
 	gRegistrar *Registrar = NewRegistrar()
 	gService   micro.Service
 	gAddress   string
+	gNodes     *Nodes
+	gLocator   *Locator
+	gMailbox   *Mailbox
+	gTopics    *Topics
+	gPresence  *Presence
+	gReceipts  *Receipts
+	gHistory   *History
+	gScheduler *Scheduler
+	gAdmin     *Admin
+	gHooks     *Hooks
+	gVerifier  Verifier
+	gPolicy    *Policy
+	gLimits    *RateLimits
+	mailboxTTL time.Duration
+	historyTTL time.Duration
+	drainGrace time.Duration
+	// hookConfigs are the hooks of gHooks, which is disabled if nil
+	hookConfigs []HookConfig
+	// syncNodes are the etcd nodes electing the leader of gScheduler, which is every node of its own store if empty
+	syncNodes []string
+	// trustedProxies are the proxies whose X-Forwarded-For gives the client address, e.g. the API gateways
+	trustedProxies []*net.IPNet
+	// historyLimit is the number of events of gHistory kept for each conversation
+	historyLimit int
+	// historyUserLimits override historyLimit for the conversations of some users
+	historyUserLimits map[string]int
+	// policyRules are the rules of gPolicy, which is disabled if nil
+	policyRules []*auth.Rule
 )
 
 func main() {
 	service := micro.NewService(
 		micro.Name(MicroServiceName),
 		micro.BeforeStop(func() error {
+			gScheduler.Stop()
+			gRegistrar.drain(drainGrace)
 			gRegistrar.close()
+			gHooks.Stop()
 			return nil
 		}),
 		micro.Flags(&cli.StringFlag{
 			Name:    "pprof_address",
 			EnvVars: []string{"PPROF_ADDRESS"},
-			Usage:   "Bind address for pprof and grpc.EnableTracing. 127.0.0.1:6060",
+			Usage:   "Bind address for pprof, grpc.EnableTracing and the Prometheus /metrics. 127.0.0.1:6060",
+		}, &cli.IntFlag{
+			Name:    "event_queue_size",
+			EnvVars: []string{"EVENT_QUEUE_SIZE"},
+			Usage:   "Number of events buffered for each channel",
+			Value:   DefaultEventQueueSize,
+		}, &cli.DurationFlag{
+			Name:    "mailbox_ttl",
+			EnvVars: []string{"MAILBOX_TTL"},
+			Usage:   "Keep undelivered events in the store for this long, until the user comes back. Disabled if zero. 24h",
+		}, &cli.DurationFlag{
+			Name:    "drain_grace",
+			EnvVars: []string{"DRAIN_GRACE"},
+			Usage:   "On shutdown, wait for this long for the clients to reconnect to other nodes",
+			Value:   DefaultDrainGrace,
+		}, &cli.StringFlag{
+			Name:    "token_public_key",
+			EnvVars: []string{"TOKEN_PUBLIC_KEY"},
+			Usage:   "Base64 encoded PEM of the RSA public key verifying the JWT bearer tokens of clients. Authentication is disabled if empty",
+		}, &cli.StringFlag{
+			Name:    "publish_policy",
+			EnvVars: []string{"PUBLISH_POLICY"},
+			Usage:   "JSON file of the rules on who may publish which event type to whom. Everyone may publish anything if empty",
+		}, &cli.StringFlag{
+			Name:    "publisher_limit",
+			EnvVars: []string{"PUBLISHER_LIMIT"},
+			Usage:   "Events per second each account or client address may publish, as rate[:burst]. Unlimited if empty. 100:200",
+		}, &cli.StringFlag{
+			Name:    "trusted_proxies",
+			EnvVars: []string{"TRUSTED_PROXIES"},
+			Usage:   "Comma separated IPs or CIDRs of the API gateways and proxies trusted to forward the client address by X-Forwarded-For. 10.0.0.0/8",
+		}, &cli.StringFlag{
+			Name:    "recipient_limit",
+			EnvVars: []string{"RECIPIENT_LIMIT"},
+			Usage:   "Events per second each user may receive by unicast or multicast, as rate[:burst]. Unlimited if empty",
+		}, &cli.StringFlag{
+			Name:    "connect_limit",
+			EnvVars: []string{"CONNECT_LIMIT"},
+			Usage:   "Connects per second of each user, as rate[:burst]. Unlimited if empty. 0.2:5",
+		}, &cli.DurationFlag{
+			Name:    "history_ttl",
+			EnvVars: []string{"HISTORY_TTL"},
+			Usage:   "Keep the unicast, multicast and group events of each conversation for this long, fetched by History.Fetch. Disabled if zero. 720h",
+		}, &cli.IntFlag{
+			Name:    "history_limit",
+			EnvVars: []string{"HISTORY_LIMIT"},
+			Usage:   "Number of the latest events kept for each conversation, e.g. each user. Unlimited if zero",
+			Value:   DefaultHistoryLimit,
+		}, &cli.StringFlag{
+			Name:    "history_user_limits",
+			EnvVars: []string{"HISTORY_USER_LIMITS"},
+			Usage:   "Comma separated user_id=limit overriding --history_limit for the conversations of some users. bot=10000,guest=20",
+		}, &cli.StringFlag{
+			Name:    "schemas",
+			EnvVars: []string{"SCHEMAS"},
+			Usage:   "FileDescriptorSet file of the message types for the type_url of events, by protoc --include_imports --descriptor_set_out",
+		}, &cli.StringFlag{
+			Name:    "hooks",
+			EnvVars: []string{"HOOKS"},
+			Usage:   "JSON file of the HTTP endpoints or broker topics notified of connects, disconnects, timeouts and undeliverable events. Disabled if empty",
+		}, &cli.StringFlag{
+			Name:    "sync_address",
+			EnvVars: []string{"SYNC_ADDRESS"},
+			Usage:   "Comma separated etcd addresses electing the node firing the scheduled deliveries. Needed to schedule with a shared store",
 		}),
+		micro.WrapHandler(verifyForwarded, rateLimit),
 		micro.Action(func(ctx *cli.Context) error {
+			gRegistrar.queueSize = ctx.Int("event_queue_size")
+			mailboxTTL = ctx.Duration("mailbox_ttl")
+			historyTTL = ctx.Duration("history_ttl")
+			historyLimit = ctx.Int("history_limit")
+			if s := ctx.String("history_user_limits"); len(s) > 0 {
+				limits, err := ParseHistoryLimits(s)
+				if err != nil {
+					logger.Errorf("history_user_limits %q: %v", s, err)
+					return err
+				}
+				historyUserLimits = limits
+			}
+			drainGrace = ctx.Duration("drain_grace")
+			if addrs := ctx.String("sync_address"); len(addrs) > 0 {
+				syncNodes = strings.Split(addrs, ",")
+			}
+			if s := ctx.String("trusted_proxies"); len(s) > 0 {
+				proxies, err := ParseProxies(s)
+				if err != nil {
+					logger.Errorf("trusted_proxies %q: %v", s, err)
+					return err
+				}
+				trustedProxies = proxies
+			}
+			if key := ctx.String("token_public_key"); len(key) > 0 {
+				gVerifier = TokenVerifier{Provider: jwt.NewTokenProvider(token.WithPublicKey(key))}
+			}
+			if path := ctx.String("publish_policy"); len(path) > 0 {
+				rules, err := LoadPolicyRules(path)
+				if err != nil {
+					logger.Errorf("load publish policy %q: %v", path, err)
+					return err
+				}
+				policyRules = rules
+			}
+			if path := ctx.String("hooks"); len(path) > 0 {
+				configs, err := LoadHooks(path)
+				if err != nil {
+					logger.Errorf("load hooks %q: %v", path, err)
+					return err
+				}
+				hookConfigs = configs
+			}
+			if path := ctx.String("schemas"); len(path) > 0 {
+				n, err := codec.RegisterSchemas(path)
+				if err != nil {
+					logger.Errorf("load schemas %q: %v", path, err)
+					return err
+				}
+				logger.Infof("%v message types loaded from %q", n, path)
+			}
+			limits := new(RateLimits)
+			for name, limiter := range map[string]**Limiter{
+				"publisher_limit": &limits.Publisher,
+				"recipient_limit": &limits.Recipient,
+				"connect_limit":   &limits.Connect,
+			} {
+				if s := ctx.String(name); len(s) > 0 {
+					limit, err := ParseLimit(s)
+					if err != nil {
+						logger.Errorf("%v %q: %v", name, s, err)
+						return err
+					}
+					*limiter = NewLimiter(limit)
+					gLimits = limits
+				}
+			}
 			if addr := ctx.String("pprof_address"); len(addr) > 0 {
 				// for pprof and trace
 				grpc.EnableTracing = true
 				trace.AuthRequest = func(req *http.Request) (any, sensitive bool) {
 					return true, true
 				}
+				http.Handle("/metrics", gMetrics)
 				go func() { logger.Warn(http.ListenAndServe(addr, nil)) }()
 			}
 			return nil
@@ -78,22 +273,84 @@ func main() {
 			}
 			logger.Infof("my address in registry is %v", myNode.Address)
 			gAddress = myNode.Address
+			// the server connects the broker only for its own subscribers, while topics need it anyway
+			if err := gService.Options().Broker.Connect(); err != nil {
+				logger.Errorf("connect broker: %v", err)
+				return err
+			}
+			if err := gPresence.Start(); err != nil {
+				logger.Errorf("subscribe presence: %v", err)
+				return err
+			}
+			if err := gReceipts.Start(); err != nil {
+				logger.Errorf("subscribe receipts: %v", err)
+				return err
+			}
+			gScheduler.Start()
+			if gHooks != nil {
+				gHooks.Start()
+			}
 			return nil
 		}),
 	)
 
 	service.Init()
+	// detect dead client connections at the transport, in addition to the channel inactivity
+	service.Server().Init(grpcserver.Options(
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    KeepaliveTime,
+			Timeout: KeepaliveTimeout,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             KeepaliveTimeout,
+			PermitWithoutStream: true,
+		}),
+	))
 	gService = service
+	gNodes = NewNodes(service.Options().Registry)
+	gLocator = NewLocator(service.Options().Store)
+	gTopics = NewTopics(service.Options().Broker)
+	gReceipts = NewReceipts(service.Options().Broker)
+	if hookConfigs != nil {
+		gHooks = NewHooks(hookConfigs, service.Options().Broker, service.Options().Store)
+	}
+	if mailboxTTL > 0 {
+		gMailbox = NewMailbox(service.Options().Store, mailboxTTL)
+	}
 
 	proto.RegisterHubHandler(service.Server(), gRegistrar)
 	proto.RegisterStreamerHandler(service.Server(), gRegistrar)
-	proto.RegisterPublisherHandler(service.Server(), new(Publisher))
+	publisher := new(Publisher)
+	gRegistrar.publisher = publisher
+	proto.RegisterPublisherHandler(service.Server(), publisher)
+	var elector sync.Sync
+	if len(syncNodes) > 0 {
+		elector = etcd.NewSync(sync.Nodes(syncNodes...))
+	} else if service.Options().Store.String() == "memory" {
+		// a node of its own store fires its own deliveries
+		elector = memory.NewSync()
+	}
+	gScheduler = NewScheduler(service.Options().Store, elector, publisher)
+	groups := NewGroups(service.Options().Store, publisher)
+	gPresence = NewPresence(service.Options().Store, service.Options().Broker, groups)
+	if policyRules != nil {
+		gPolicy = NewPolicy(policyRules, groups)
+	}
+	proto.RegisterGroupHandler(service.Server(), groups)
+	if historyTTL > 0 {
+		gHistory = NewHistory(service.Options().Store, groups, historyTTL, historyLimit, historyUserLimits)
+		proto.RegisterHistoryHandler(service.Server(), gHistory)
+	}
+	proto.RegisterPresenceHandler(service.Server(), gPresence)
+	gAdmin = NewAdmin(service.Options().Store, publisher)
+	proto.RegisterAdminHandler(service.Server(), gAdmin)
 
 	logger.Info("run")
 	ticker := time.NewTicker(HousekeepInterval)
 	go func() {
 		for range ticker.C {
 			gRegistrar.housekeep()
+			gLimits.purge()
 		}
 	}()
 	if err := service.Run(); err != nil {
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"time"
+
+	"github.com/aclisp/sims/pkg/metrics"
+)
+
+// gMetrics is served at /metrics of the pprof address
+var gMetrics = metrics.NewRegistry()
+
+var (
+	metricConnects = gMetrics.NewCounter("sims_connects_total",
+		"Channels connected.")
+	metricDisconnects = gMetrics.NewCounter("sims_disconnects_total",
+		"Channels closed by Hub.Disconnect, Admin.Kick or the shutdown, by reason.", "reason")
+	metricEvictions = gMetrics.NewCounter("sims_housekeep_evictions_total",
+		"Channels closed by housekeeping for inactivity.")
+	metricUnicasts = gMetrics.NewCounter("sims_unicast_total",
+		"Results of Publisher.Unicast from clients, by ErrorCode or OK.", "code")
+	metricQueueWait = gMetrics.NewHistogram("sims_event_queue_wait_seconds",
+		"Time from publishing an event to sending it to a stream, including the time kept in the mailbox.", metrics.DefBuckets)
+	metricSendLatency = gMetrics.NewHistogram("sims_stream_send_seconds",
+		"Time of sending an event to a stream.", metrics.DefBuckets)
+)
+
+func init() {
+	gMetrics.NewGaugeFunc("sims_channels", "Channels connected to this node.", func() float64 {
+		return float64(gRegistrar.size.Load())
+	})
+}
+
+// resultCode names the ErrorCode of err, or OK
+func resultCode(err error) string {
+	if err == nil {
+		return "OK"
+	}
+	return errorCode(err).String()
+}
+
+// since returns the seconds elapsed since the unix time in milliseconds
+func since(ms int64) float64 {
+	return time.Since(time.Unix(0, ms*int64(time.Millisecond))).Seconds()
+}
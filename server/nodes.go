@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/micro/go-micro/v2/logger"
+	"github.com/micro/go-micro/v2/metadata"
+	"github.com/micro/go-micro/v2/registry"
+	"github.com/micro/go-micro/v2/server"
+)
+
+// NodesRefreshInterval is how often the addresses of the registered nodes are looked up again, at most
+const NodesRefreshInterval = 5 * time.Second
+
+type forwardedKey struct{}
+
+// Nodes caches the addresses of the SIMS nodes in the registry, which may forward requests to each other
+type Nodes struct {
+	registry registry.Registry
+
+	lock      sync.Mutex
+	addresses map[string]bool
+	updated   time.Time
+}
+
+// NewNodes creates the cache of the SIMS nodes in r
+func NewNodes(r registry.Registry) *Nodes {
+	return &Nodes{registry: r}
+}
+
+// registered tells if address is of a SIMS node. The registry is looked up again when the cache is stale,
+// but not on every unknown address, which may be forged.
+func (n *Nodes) registered(address string) bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if time.Since(n.updated) >= NodesRefreshInterval {
+		n.refresh()
+	}
+	return n.addresses[address]
+}
+
+func (n *Nodes) refresh() {
+	services, err := n.registry.GetService(MicroServiceName)
+	if err != nil {
+		logger.Errorf("get service %q from registry: %v", MicroServiceName, err)
+		return
+	}
+	addresses := make(map[string]bool)
+	for _, service := range services {
+		for _, node := range service.Nodes {
+			addresses[node.Address] = true
+		}
+	}
+	n.addresses = addresses
+	n.updated = time.Now()
+}
+
+// verify tells if a request marked as forwarded by the node at address comes from that node indeed
+func (n *Nodes) verify(ctx context.Context, address string) bool {
+	if n == nil {
+		return false
+	}
+	remote, _ := metadata.Get(ctx, "Remote")
+	remoteHost, _, err := net.SplitHostPort(remote)
+	if err != nil {
+		return false
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil || host != remoteHost {
+		return false
+	}
+	return n.registered(address)
+}
+
+// verifyForwarded is a server.HandlerWrapper honoring the forwarded mark of a request only from the node it names,
+// since any client may send the mark. isForwarded is false for the requests whose mark is dropped.
+func verifyForwarded(fn server.HandlerFunc) server.HandlerFunc {
+	return func(ctx context.Context, req server.Request, rsp interface{}) error {
+		address, ok := metadata.Get(ctx, metadataForwarded)
+		if !ok {
+			return fn(ctx, req, rsp)
+		}
+		if gNodes.verify(ctx, address) {
+			return fn(context.WithValue(ctx, forwardedKey{}, address), req, rsp)
+		}
+		remote, _ := metadata.Get(ctx, "Remote")
+		logger.Warnf("drop the forwarded mark of %v from %v, which is not that node", address, remote)
+		md, _ := metadata.FromContext(ctx)
+		md.Delete(metadataForwarded)
+		md.Delete(metadataOrigin)
+		return fn(metadata.NewContext(ctx, md), req, rsp)
+	}
+}
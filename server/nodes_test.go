@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/micro/go-micro/v2/metadata"
+	"github.com/micro/go-micro/v2/registry"
+	"github.com/micro/go-micro/v2/registry/memory"
+	"github.com/micro/go-micro/v2/server"
+)
+
+func TestVerifyForwarded(t *testing.T) {
+	r := memory.NewRegistry()
+	if err := r.Register(&registry.Service{
+		Name:  MicroServiceName,
+		Nodes: []*registry.Node{{Id: "node", Address: "10.0.0.1:9000"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	gNodes = NewNodes(r)
+	defer func() { gNodes = nil }()
+
+	tests := []struct {
+		name   string
+		md     metadata.Metadata
+		want   bool
+		origin bool
+	}{
+		{"node", metadata.Metadata{"Remote": "10.0.0.1:51000", metadataForwarded: "10.0.0.1:9000", metadataOrigin: "key"}, true, true},
+		{"client", metadata.Metadata{"Remote": "10.0.0.2:51000", metadataForwarded: "10.0.0.1:9000", metadataOrigin: "key"}, false, false},
+		{"title case", metadata.Metadata{"Remote": "10.0.0.2:51000", "Sims-Forwarded": "10.0.0.1:9000"}, false, false},
+		{"not registered", metadata.Metadata{"Remote": "10.0.0.3:51000", metadataForwarded: "10.0.0.3:9000"}, false, false},
+		{"not marked", metadata.Metadata{"Remote": "10.0.0.1:51000"}, false, false},
+	}
+	for _, tt := range tests {
+		var forwarded, origin bool
+		handler := verifyForwarded(func(ctx context.Context, req server.Request, rsp interface{}) error {
+			forwarded = isForwarded(ctx)
+			_, origin = metadata.Get(ctx, metadataOrigin)
+			return nil
+		})
+		if err := handler(metadata.NewContext(context.Background(), tt.md), nil, nil); err != nil {
+			t.Fatal(err)
+		}
+		if forwarded != tt.want || origin != tt.origin {
+			t.Errorf("%v: forwarded %v with origin %v, want %v with %v", tt.name, forwarded, origin, tt.want, tt.origin)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/aclisp/sims/proto"
+	"github.com/micro/go-micro/v2/auth"
+	"github.com/micro/go-micro/v2/auth/rules"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/logger"
+)
+
+// publishResource is the auth.Resource type of publishing events.
+// The name of a resource is the EventType, and the endpoint is the recipient:
+//
+//	user/<user_id>   a user
+//	group/<group_id> a user sharing the group with the publisher
+//	topic/<topic>    the subscribers of a topic
+const publishResource = "publish"
+
+// PolicyRule is the configuration of an auth.Rule on publishing
+type PolicyRule struct {
+	ID        string `json:"id"`
+	Scope     string `json:"scope"`      // empty for everyone, "*" for any authenticated account, or a scope of the account
+	EventType string `json:"event_type"` // EventType name or "*"
+	Recipient string `json:"recipient"`  // e.g. "user/*", "group/*", "topic/news" or "*"
+	Access    string `json:"access"`     // "granted" or "denied"
+	Priority  int32  `json:"priority"`
+}
+
+// Policy decides who may publish which EventType to whom, by the auth.Rule model
+type Policy struct {
+	rules  []*auth.Rule
+	groups *Groups
+}
+
+// LoadPolicyRules reads the rules from a JSON file of PolicyRule array
+func LoadPolicyRules(path string) ([]*auth.Rule, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var configs []PolicyRule
+	if err := json.Unmarshal(data, &configs); err != nil {
+		return nil, err
+	}
+	result := make([]*auth.Rule, 0, len(configs))
+	for _, c := range configs {
+		var access auth.Access
+		switch strings.ToLower(c.Access) {
+		case "granted":
+			access = auth.AccessGranted
+		case "denied":
+			access = auth.AccessDenied
+		default:
+			return nil, fmt.Errorf("rule %q: invalid access %q", c.ID, c.Access)
+		}
+		result = append(result, &auth.Rule{
+			ID:    c.ID,
+			Scope: c.Scope,
+			Resource: &auth.Resource{
+				Type:     publishResource,
+				Name:     c.EventType,
+				Endpoint: c.Recipient,
+			},
+			Access:   access,
+			Priority: c.Priority,
+		})
+	}
+	return result, nil
+}
+
+// NewPolicy creates a publishing policy of rules, which looks up shared groups in groups
+func NewPolicy(rules []*auth.Rule, groups *Groups) *Policy {
+	return &Policy{
+		rules:  rules,
+		groups: groups,
+	}
+}
+
+func errorPermissionDenied(format string, a ...interface{}) error {
+	return errors.Forbidden(proto.ErrorCode_ERR_PERMISSION_DENIED.String(), format, a...)
+}
+
+func accountID(account *auth.Account) string {
+	if account == nil {
+		return "anonymous"
+	}
+	return account.ID
+}
+
+// verify tells if any of the recipient endpoints is granted to account
+func (p *Policy) verify(account *auth.Account, eventType proto.EventType, endpoints []string) bool {
+	for _, endpoint := range endpoints {
+		err := rules.Verify(p.rules, account, &auth.Resource{
+			Type:     publishResource,
+			Name:     eventType.String(),
+			Endpoint: endpoint,
+		})
+		if err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// sharedGroups returns the groups both users are members of
+func (p *Policy) sharedGroups(userA, userB string) []string {
+	groupsA, err := p.groups.groupsOf(userA)
+	if err != nil {
+		logger.Errorf("[%v] read groups error: %v", userA, err)
+		return nil
+	}
+	if len(groupsA) == 0 {
+		return nil
+	}
+	groupsB, err := p.groups.groupsOf(userB)
+	if err != nil {
+		logger.Errorf("[%v] read groups error: %v", userB, err)
+		return nil
+	}
+	in := make(map[string]bool, len(groupsA))
+	for _, g := range groupsA {
+		in[g] = true
+	}
+	var shared []string
+	for _, g := range groupsB {
+		if in[g] {
+			shared = append(shared, g)
+		}
+	}
+	return shared
+}
+
+// AllowUser tells if account may publish event to a user
+func (p *Policy) AllowUser(account *auth.Account, event *proto.Event, userID string) error {
+	if p == nil {
+		return nil
+	}
+	endpoints := []string{"user/" + userID}
+	if account != nil {
+		for _, g := range p.sharedGroups(account.ID, userID) {
+			endpoints = append(endpoints, "group/"+g)
+		}
+	}
+	if !p.verify(account, event.GetType(), endpoints) {
+		return errorPermissionDenied("%v may not publish %v to %v", accountID(account), event.GetType(), userID)
+	}
+	return nil
+}
+
+// AllowTopic tells if account may publish event to a topic
+func (p *Policy) AllowTopic(account *auth.Account, event *proto.Event, topic string) error {
+	if p == nil {
+		return nil
+	}
+	if !p.verify(account, event.GetType(), []string{"topic/" + topic}) {
+		return errorPermissionDenied("%v may not publish %v to topic %q", accountID(account), event.GetType(), topic)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/aclisp/sims/proto"
+)
+
+const (
+	// DefaultPollWait is how long a poll waits for events if not given
+	DefaultPollWait = 25 * time.Second
+	// MaxPollWait is the maximum wait of a poll
+	MaxPollWait = 2 * time.Minute
+	// PollMargin is left of the deadline of a poll request to return the events in time
+	PollMargin = 500 * time.Millisecond
+	// PollLinger is how long a poll collects more events after the first one
+	PollLinger = 50 * time.Millisecond
+	// DefaultPollEvents is the number of events returned by a poll if not given
+	DefaultPollEvents = 100
+	// MaxPollEvents is the maximum number of events returned by a poll
+	MaxPollEvents = 1000
+)
+
+// poller collects the events of a Streamer.Poll, and ends the poll shortly after the first event
+type poller struct {
+	events []*proto.Event
+	max    int
+	linger *time.Timer
+	cancel context.CancelFunc
+}
+
+// Send collects an event other than heartbeats
+func (p *poller) Send(event *proto.Event) error {
+	if event.Type == proto.EventType_EVT_HEARTBEAT {
+		return nil
+	}
+	p.events = append(p.events, event)
+	if len(p.events) >= p.max {
+		p.cancel()
+	} else if p.linger == nil {
+		p.linger = time.AfterFunc(PollLinger, p.cancel)
+	}
+	return nil
+}
+
+// pollWait returns how long a poll of req waits, within the deadline of ctx
+func pollWait(ctx context.Context, req *proto.PollRequest) time.Duration {
+	wait := time.Duration(req.WaitMs) * time.Millisecond
+	if wait <= 0 {
+		wait = DefaultPollWait
+	}
+	if wait > MaxPollWait {
+		wait = MaxPollWait
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if left := time.Until(deadline) - PollMargin; left < wait {
+			wait = left
+		}
+	}
+	return wait
+}
+
+// Poll TODO
+func (reg *Registrar) Poll(ctx context.Context, req *proto.PollRequest, res *proto.PollResponse) error {
+	trace := req.GetHeader().GetRequestId()
+	uid, err := uniqueIDFromHeader(ctx, req.Header)
+	if err != nil {
+		return err
+	}
+
+	channel := reg.findChannel(uid)
+	if channel == nil {
+		return errorNotRegistered(uid)
+	}
+	reg.alive(uid)
+
+	max := int(req.MaxEvents)
+	if max <= 0 {
+		max = DefaultPollEvents
+	}
+	if max > MaxPollEvents {
+		max = MaxPollEvents
+	}
+	// the channel is streamed to the poller until the wait is over, or the events are collected
+	pollCtx, cancel := context.WithTimeout(ctx, pollWait(ctx, req))
+	defer cancel()
+	p := &poller{max: max, cancel: cancel}
+	err = reg.stream(pollCtx, uid, trace, channel, req.Cursor, p)
+	if p.linger != nil {
+		p.linger.Stop()
+	}
+	if err != nil {
+		return err
+	}
+
+	res.Events = p.events
+	switch {
+	case len(p.events) > 0:
+		res.Cursor = p.events[len(p.events)-1].Seq + 1
+	case req.Cursor > 0:
+		res.Cursor = req.Cursor
+	default:
+		// nothing is sent, so the next poll resumes from the next event
+		res.Cursor = channel.Seq.Load() + 1
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aclisp/sims/proto"
+)
+
+func TestPoll(t *testing.T) {
+	uids := newTestRegistrar(1)
+	channel := gRegistrar.findChannel(uids[0])
+	header := &proto.Header{UserId: uids[0].UserID}
+	poll := func(cursor uint64, wait time.Duration) *proto.PollResponse {
+		res := new(proto.PollResponse)
+		req := &proto.PollRequest{Header: header, Cursor: cursor, WaitMs: int64(wait / time.Millisecond)}
+		if err := gRegistrar.Poll(context.Background(), req, res); err != nil {
+			t.Fatal(err)
+		}
+		return res
+	}
+	data := func(res *proto.PollResponse) (s string) {
+		for _, event := range res.Events {
+			s += string(event.Data)
+		}
+		return s
+	}
+
+	for _, s := range []string{"a", "b"} {
+		if err := push(uids[0], channel, &proto.Event{Type: proto.EventType_EVT_TEXT, Data: []byte(s)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if res := poll(0, time.Second); data(res) != "ab" || res.Cursor != 3 {
+		t.Fatalf("first poll returns %q and cursor %v, want \"ab\" and 3", data(res), res.Cursor)
+	}
+	// the response is lost, so the client polls from its last cursor again
+	if res := poll(1, time.Second); data(res) != "ab" || res.Cursor != 3 {
+		t.Fatalf("poll from 1 returns %q and cursor %v, want \"ab\" redelivered and 3", data(res), res.Cursor)
+	}
+
+	start := time.Now()
+	if res := poll(3, 100*time.Millisecond); len(res.Events) != 0 || res.Cursor != 3 {
+		t.Fatalf("idle poll returns %q and cursor %v, want nothing and 3", data(res), res.Cursor)
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Fatalf("idle poll returns after %v, want the wait", elapsed)
+	}
+
+	time.AfterFunc(100*time.Millisecond, func() {
+		push(uids[0], channel, &proto.Event{Type: proto.EventType_EVT_TEXT, Data: []byte("c")})
+	})
+	start = time.Now()
+	if res := poll(3, 10*time.Second); data(res) != "c" || res.Cursor != 4 {
+		t.Fatalf("waiting poll returns %q and cursor %v, want \"c\" and 4", data(res), res.Cursor)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("waiting poll returns after %v, want soon after the event", elapsed)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if wait := pollWait(ctx, &proto.PollRequest{}); wait > time.Second-PollMargin {
+		t.Fatalf("poll waits %v, want within the deadline", wait)
+	}
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aclisp/sims/proto"
+	pb "github.com/golang/protobuf/proto"
+	"github.com/micro/go-micro/v2/auth"
+	"github.com/micro/go-micro/v2/broker"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/logger"
+	"github.com/micro/go-micro/v2/store"
+)
+
+const (
+	lastSeenPrefix = "lastseen"
+	// presenceTopic is the broker topic where every node announces the presence changes of its users
+	presenceTopic = MicroServiceName + ".presence"
+	// presenceWatchBuffer is the number of presence changes buffered for each watcher
+	presenceWatchBuffer = 16
+)
+
+// Presence tells whether users are online, by the user location directory, and notifies the watchers of changes
+type Presence struct {
+	store  store.Store
+	broker broker.Broker
+	groups *Groups
+
+	lock     sync.Mutex
+	watchers map[string]map[chan *proto.UserPresence]bool // user_id => watchers
+}
+
+// NewPresence creates the presence service backed by s, which distributes presence changes by b.
+// The users sharing a group may see the presence of each other.
+func NewPresence(s store.Store, b broker.Broker, groups *Groups) *Presence {
+	return &Presence{
+		store:    s,
+		broker:   b,
+		groups:   groups,
+		watchers: make(map[string]map[chan *proto.UserPresence]bool),
+	}
+}
+
+// Start listens to the presence changes from all nodes
+func (p *Presence) Start() error {
+	_, err := p.broker.Subscribe(presenceTopic, p.handle)
+	return err
+}
+
+// presence returns the current presence of a user
+func (p *Presence) presence(userID string) *proto.UserPresence {
+	online := make(map[string]bool)
+	if gLocator != nil {
+		devices, err := gLocator.Lookup(userID)
+		if err != nil {
+			logger.Errorf("[%v] lookup location error: %v", userID, err)
+		}
+		for deviceID := range devices {
+			online[deviceID] = true
+		}
+	}
+	// locations are unavailable without a shared store
+	for uid := range gRegistrar.findChannels(userID, nil) {
+		online[uid.DeviceID] = true
+	}
+
+	presence := &proto.UserPresence{
+		UserId: userID,
+		Status: proto.PresenceStatus_PRESENCE_OFFLINE,
+	}
+	if len(online) > 0 {
+		presence.Status = proto.PresenceStatus_PRESENCE_ONLINE
+		presence.LastSeen = time.Now().UnixNano() / int64(time.Millisecond)
+		for deviceID := range online {
+			presence.DeviceId = append(presence.DeviceId, deviceID)
+		}
+		sort.Strings(presence.DeviceId)
+		return presence
+	}
+	records, err := p.store.Read(storeKey(lastSeenPrefix, userID))
+	if err != nil && err != store.ErrNotFound {
+		logger.Errorf("[%v] read last seen error: %v", userID, err)
+	}
+	if len(records) > 0 {
+		presence.LastSeen, _ = strconv.ParseInt(string(records[0].Value), 10, 64)
+	}
+	return presence
+}
+
+// Changed records that a device of a user is connected or disconnected, and announces the presence of the user
+func (p *Presence) Changed(userID string) {
+	presence := p.presence(userID)
+	if presence.Status == proto.PresenceStatus_PRESENCE_OFFLINE {
+		presence.LastSeen = time.Now().UnixNano() / int64(time.Millisecond)
+	}
+	if err := p.store.Write(&store.Record{
+		Key:   storeKey(lastSeenPrefix, userID),
+		Value: []byte(strconv.FormatInt(presence.LastSeen, 10)),
+	}); err != nil {
+		logger.Errorf("[%v] write last seen error: %v", userID, err)
+	}
+	body, err := pb.Marshal(presence)
+	if err != nil {
+		logger.Errorf("[%v] encode presence error: %v", userID, err)
+		return
+	}
+	if err := p.broker.Publish(presenceTopic, &broker.Message{
+		Header: map[string]string{"Content-Type": "application/protobuf"},
+		Body:   body,
+	}); err != nil {
+		logger.Errorf("[%v] publish presence error: %v", userID, err)
+	}
+}
+
+// handle notifies the local watchers of a presence change
+func (p *Presence) handle(e broker.Event) error {
+	presence := new(proto.UserPresence)
+	if err := pb.Unmarshal(e.Message().Body, presence); err != nil {
+		logger.Errorf("decode presence error: %v", err)
+		return err
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for watcher := range p.watchers[presence.UserId] {
+		select {
+		case watcher <- presence:
+		default:
+			logger.Warnf("[%v] presence watcher is too slow, change dropped", presence.UserId)
+		}
+	}
+	return nil
+}
+
+func (p *Presence) watch(userIDs []string) chan *proto.UserPresence {
+	watcher := make(chan *proto.UserPresence, presenceWatchBuffer)
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for _, u := range userIDs {
+		if p.watchers[u] == nil {
+			p.watchers[u] = make(map[chan *proto.UserPresence]bool)
+		}
+		p.watchers[u][watcher] = true
+	}
+	return watcher
+}
+
+func (p *Presence) unwatch(userIDs []string, watcher chan *proto.UserPresence) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for _, u := range userIDs {
+		delete(p.watchers[u], watcher)
+		if len(p.watchers[u]) == 0 {
+			delete(p.watchers, u)
+		}
+	}
+}
+
+// allowWatch tells if the caller may see the presence of users: its own, of the users sharing a group with it,
+// or of any if of the service scope
+func (p *Presence) allowWatch(account *auth.Account, userIDs []string) error {
+	if account == nil {
+		// authentication is disabled
+		return nil
+	}
+	if hasScope(account, serviceScope) {
+		return nil
+	}
+	var groups []string
+	for _, u := range userIDs {
+		if u == account.ID {
+			continue
+		}
+		if groups == nil {
+			var err error
+			if groups, err = p.groups.groupsOf(account.ID); err != nil {
+				return errorInternal("read groups of %v: %v", account.ID, err)
+			}
+		}
+		shared := false
+		for _, g := range groups {
+			ok, err := p.groups.isMember(g, u)
+			if err != nil {
+				return err
+			}
+			if ok {
+				shared = true
+				break
+			}
+		}
+		if !shared {
+			return errorPermissionDenied("%v may not see the presence of %v", account.ID, u)
+		}
+	}
+	return nil
+}
+
+// GetPresence TODO
+func (p *Presence) GetPresence(ctx context.Context, req *proto.GetPresenceRequest, res *proto.GetPresenceResponse) error {
+	if len(req.UserId) == 0 {
+		return errors.BadRequest(proto.ErrorCode_ERR_MISSING_USERID.String(), "need at least one user_id")
+	}
+	account, err := authenticate(ctx)
+	if err != nil {
+		return err
+	}
+	if err := p.allowWatch(account, req.UserId); err != nil {
+		return err
+	}
+	res.Presence = make(map[string]*proto.UserPresence, len(req.UserId))
+	for _, u := range req.UserId {
+		res.Presence[u] = p.presence(u)
+	}
+	return nil
+}
+
+// WatchPresence TODO
+func (p *Presence) WatchPresence(ctx context.Context, req *proto.WatchPresenceRequest, stream proto.Presence_WatchPresenceStream) error {
+	if len(req.UserId) == 0 {
+		return errors.BadRequest(proto.ErrorCode_ERR_MISSING_USERID.String(), "need at least one user_id")
+	}
+	account, err := authenticate(ctx)
+	if err != nil {
+		return err
+	}
+	if err := p.allowWatch(account, req.UserId); err != nil {
+		return err
+	}
+	// watch before getting the current presence, so that no change is missed in between
+	watcher := p.watch(req.UserId)
+	defer p.unwatch(req.UserId, watcher)
+
+	for _, u := range req.UserId {
+		if err := stream.Send(p.presence(u)); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case presence := <-watcher:
+			if err := stream.Send(presence); err != nil {
+				return err
+			}
+		}
+	}
+}
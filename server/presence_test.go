@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aclisp/sims/proto"
+	"github.com/micro/go-micro/v2/store/memory"
+)
+
+func TestPresenceAuthorization(t *testing.T) {
+	gVerifier = testVerifier{}
+	defer func() { gVerifier = nil }()
+	s := memory.NewStore()
+	groups := NewGroups(s, nil)
+	p := NewPresence(s, nil, groups)
+	if err := groups.Create(withToken(serviceScope), &proto.CreateGroupRequest{GroupId: "g", UserId: []string{"a", "b"}}, new(proto.CreateGroupResponse)); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		ctx   context.Context
+		users []string
+		want  proto.ErrorCode
+	}{
+		{withToken("a"), []string{"a", "b"}, proto.ErrorCode_ERR_UNSPECIFIED},
+		{withToken("a"), []string{"b", "c"}, proto.ErrorCode_ERR_PERMISSION_DENIED},
+		{withToken("c"), []string{"a"}, proto.ErrorCode_ERR_PERMISSION_DENIED},
+		{withToken(serviceScope), []string{"a", "c"}, proto.ErrorCode_ERR_UNSPECIFIED},
+		{context.Background(), []string{"a"}, proto.ErrorCode_ERR_UNAUTHENTICATED},
+	} {
+		err := p.GetPresence(c.ctx, &proto.GetPresenceRequest{UserId: c.users}, new(proto.GetPresenceResponse))
+		if (err == nil) != (c.want == proto.ErrorCode_ERR_UNSPECIFIED) || (err != nil && errorCode(err) != c.want) {
+			t.Errorf("get presence of %v: %v, want %v", c.users, err, c.want)
+		}
+	}
+}
@@ -2,58 +2,332 @@ package main
 
 import (
 	"context"
+	"time"
 
+	"github.com/aclisp/sims/pkg/codec"
 	"github.com/aclisp/sims/proto"
+	"github.com/google/uuid"
+	"github.com/micro/go-micro/v2/client"
 	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/logger"
+	"github.com/micro/go-micro/v2/metadata"
 )
 
+// metadataForwarded marks a publishing request forwarded from another SIMS node
+const metadataForwarded = "sims-forwarded"
+
 // Publisher TODO
 type Publisher struct{}
 
+// isForwarded tells if the request comes from another SIMS node, which should not be forwarded again.
+// Only the marks verified by verifyForwarded count.
+func isForwarded(ctx context.Context) bool {
+	_, ok := ctx.Value(forwardedKey{}).(string)
+	return ok
+}
+
+// forward returns a Publisher client and call options, which are bound to the SIMS node at address
+func forward(ctx context.Context, address string) (context.Context, proto.PublisherService, client.CallOption) {
+	ctx = metadata.Set(ctx, metadataForwarded, gAddress)
+	if o, ok := originOf(ctx); ok && o.key != "" {
+		ctx = metadata.Set(ctx, metadataOrigin, o.key)
+	}
+	return ctx, proto.NewPublisherService(MicroServiceName, gService.Client()), client.WithAddress(address)
+}
+
+// locate returns the addresses of the remote nodes a user is connected to
+func (pub *Publisher) locate(ctx context.Context, userID string) []string {
+	if isForwarded(ctx) || gLocator == nil {
+		return nil
+	}
+	devices, err := gLocator.Lookup(userID)
+	if err != nil {
+		logger.Errorf("[%v] lookup location error: %v", userID, err)
+		return nil
+	}
+	var addresses []string
+	seen := make(map[string]bool)
+	for _, address := range devices {
+		if address == gAddress || seen[address] {
+			continue
+		}
+		seen[address] = true
+		addresses = append(addresses, address)
+	}
+	return addresses
+}
+
+// mergeDelivery merges the results of delivering to different devices of a user: any success is a success
+func mergeDelivery(a, b error) error {
+	if a == nil || b == nil {
+		return nil
+	}
+	if errorCode(a) == proto.ErrorCode_ERR_NOT_FOUND {
+		return b
+	}
+	return a
+}
+
+func validateEvent(uid UniqueID, event *proto.Event) error {
+	if event == nil {
+		return errors.BadRequest(proto.ErrorCode_ERR_MISSING_EVENT.String(), "nil event for %v", uid)
+	}
+	switch event.Type {
+	case proto.EventType_EVT_HEARTBEAT, proto.EventType_EVT_RECONNECT, proto.EventType_EVT_KICKED:
+		return errors.BadRequest(proto.ErrorCode_ERR_INVALID_EVENT_TYPE.String(), "event type should not be %v", event.Type)
+	}
+	return validatePayload(event)
+}
+
+// validatePayload checks the data of an event against its type_url, if any
+func validatePayload(event *proto.Event) error {
+	if event.TypeUrl == "" {
+		return nil
+	}
+	if event.Type != proto.EventType_EVT_JSON && event.Type != proto.EventType_EVT_PROTOBUF {
+		return errors.BadRequest(proto.ErrorCode_ERR_INVALID_PAYLOAD.String(), "type_url is not for %v", event.Type)
+	}
+	typeURL, protobuf := event.PayloadType()
+	if _, err := codec.Decode(typeURL, protobuf, event.Data); err != nil {
+		return errors.BadRequest(proto.ErrorCode_ERR_INVALID_PAYLOAD.String(), "%v", err)
+	}
+	return nil
+}
+
+// stamp sets the server time, the id and the trace of event, unless they are already set by the node forwarding it.
+// The receipts are only requested by Unicast.
+func stamp(ctx context.Context, event *proto.Event) {
+	forwarded := isForwarded(ctx)
+	if event.Timestamp == 0 || !forwarded {
+		event.Timestamp = time.Now().UnixNano() / int64(time.Millisecond)
+	}
+	if event.Id == "" {
+		event.Id = uuid.New().String()
+	}
+	if !forwarded {
+		event.ReceiptTo = ""
+		traceEvent(ctx, event)
+	}
+}
+
+// expiresIn returns how long event is still deliverable, and false if it never expires
+func expiresIn(event *proto.Event) (time.Duration, bool) {
+	if event.TtlMs <= 0 {
+		return 0, false
+	}
+	expiry := time.Unix(0, (event.Timestamp+event.TtlMs)*int64(time.Millisecond))
+	return time.Until(expiry), true
+}
+
+// expired tells if event is past its TTL
+func expired(event *proto.Event) bool {
+	ttl, ok := expiresIn(event)
+	return ok && ttl <= 0
+}
+
+// deliver pushes event to every device of a user connected to this node, which is matched by selector.
+// Events overflowing the queue of a device are kept in the mailbox, if enabled.
+func (pub *Publisher) deliver(userID string, selector *proto.Selector, event *proto.Event) error {
+	channels := gRegistrar.findChannels(userID, selector)
+	if len(channels) == 0 {
+		return errorNotRegistered(UniqueID{UserID: userID})
+	}
+	var err error
+	for uid, channel := range channels {
+		err = mergeDelivery(err, push(uid, channel, event))
+	}
+	return err
+}
+
+// push enqueues event to the channel of uid. Events overflowing the queue are kept in the mailbox, if enabled.
+func push(uid UniqueID, channel *Channel, event *proto.Event) error {
+	if channel.offer(event) || gRegistrar.keep(uid, channel, event) {
+		return nil
+	}
+	return errorNoConsumer(uid)
+}
+
+// keep puts event into the mailbox of an offline user, if enabled and not forwarded
+func (pub *Publisher) keep(ctx context.Context, userID string, selector *proto.Selector, event *proto.Event, err error) error {
+	if gMailbox == nil || isForwarded(ctx) || errorCode(err) != proto.ErrorCode_ERR_NOT_FOUND {
+		return err
+	}
+	uid := UniqueID{UserID: userID}
+	if perr := gMailbox.Put(uid, selector, event); perr != nil {
+		logger.Errorf("[%v] keep event in mailbox error: %v", uid, perr)
+		return err
+	}
+	return nil
+}
+
 // Unicast TODO
 func (pub *Publisher) Unicast(ctx context.Context, req *proto.UnicastRequest, res *proto.UnicastResponse) error {
+	err := pub.unicast(ctx, req, res)
+	if !isForwarded(ctx) {
+		metricUnicasts.Inc(resultCode(err))
+	}
+	return err
+}
+
+func (pub *Publisher) unicast(ctx context.Context, req *proto.UnicastRequest, res *proto.UnicastResponse) error {
 	uid := UniqueID{
 		UserID: req.UserId,
 	}
-	events := gRegistrar.findEventQueue(uid)
-	if events == nil {
-		return errorNotRegistered(uid)
+	if err := validateEvent(uid, req.Event); err != nil {
+		return err
 	}
-	if req.Event == nil {
-		return errors.BadRequest(proto.ErrorCode_ERR_MISSING_EVENT.String(), "nil event for %v", uid)
+	account, err := caller(ctx)
+	if err != nil {
+		return err
+	}
+	if err := gPolicy.AllowUser(account, req.Event, req.UserId); err != nil {
+		return err
 	}
-	if req.Event.Type == proto.EventType_EVT_HEARTBEAT {
-		return errors.BadRequest(proto.ErrorCode_ERR_INVALID_EVENT_TYPE.String(), "event type should not be EVT_HEARTBEAT")
+	stamp(ctx, req.Event)
+	if req.RequestReceipt && !isForwarded(ctx) {
+		if req.Event.ReceiptTo, err = publisherKey(ctx); err != nil {
+			return err
+		}
 	}
-	event := req.Event
-	select {
-	case events <- event:
-	default:
-		return errorNoConsumer(uid)
+	res.EventId = req.Event.Id
+	res.TraceId = req.Event.TraceId
+	if scheduled(ctx, req.DeliverAt) {
+		res.ScheduleId, err = gScheduler.schedule(ctx, account, &proto.Schedule{DeliverAt: req.DeliverAt, Unicast: req})
+		return err
 	}
-	return nil
+	if !isForwarded(ctx) {
+		gHistory.record(userConversation(req.UserId), req.Event)
+	}
+	err = pub.deliver(req.UserId, req.UserSelector, req.Event)
+	for _, address := range pub.locate(ctx, req.UserId) {
+		ctx, node, opt := forward(ctx, address)
+		_, ferr := node.Unicast(ctx, req, opt)
+		err = mergeDelivery(err, ferr)
+	}
+	err = pub.keep(ctx, req.UserId, req.UserSelector, req.Event, err)
+	notifyUndeliverable(ctx, req.UserId, req.Event, err)
+	return err
 }
 
 // Multicast TODO
 func (pub *Publisher) Multicast(ctx context.Context, req *proto.MulticastRequest, res *proto.MulticastResponse) error {
+	return pub.multicast(ctx, req, res, true)
+}
+
+// multicast publishes to every user of req, and keeps the event in the conversation of each if recorded.
+// Group.Broadcast keeps it in the conversation of the group instead.
+func (pub *Publisher) multicast(ctx context.Context, req *proto.MulticastRequest, res *proto.MulticastResponse, recorded bool) error {
 	if len(req.UserId) == 0 {
 		return errors.BadRequest(proto.ErrorCode_ERR_MISSING_USERID.String(), "need at least one user_id")
 	}
-	res.UserErrcode = make(map[string]proto.ErrorCode)
+	account, err := caller(ctx)
+	if err != nil {
+		return err
+	}
+	if req.Event != nil {
+		stamp(ctx, req.Event)
+	}
+	results := make(map[string]error, len(req.UserId))
+	var allowed []string
 	for _, u := range req.UserId {
-		if err := pub.Unicast(ctx, &proto.UnicastRequest{
-			UserId:       u,
-			Event:        req.Event,
-			UserSelector: req.UserSelector[u],
-		}, &proto.UnicastResponse{}); err != nil {
-			var errCode proto.ErrorCode
-			if ierr, ok := err.(*errors.Error); ok {
-				errCode = proto.ErrorCode(proto.ErrorCode_value[ierr.Id])
-			} else {
-				errCode = proto.ErrorCode_ERR_UNSPECIFIED
+		err := validateEvent(UniqueID{UserID: u}, req.Event)
+		if err == nil {
+			err = gPolicy.AllowUser(account, req.Event, u)
+		}
+		if err == nil {
+			allowed = append(allowed, u)
+		}
+		results[u] = err
+	}
+	res.UserErrcode = make(map[string]proto.ErrorCode)
+	if scheduled(ctx, req.DeliverAt) {
+		for u, err := range results {
+			if err != nil {
+				res.UserErrcode[u] = errorCode(err)
 			}
-			res.UserErrcode[u] = errCode
 		}
+		if len(allowed) == 0 {
+			return nil
+		}
+		later := *req
+		later.UserId = allowed
+		res.ScheduleId, err = gScheduler.schedule(ctx, account, &proto.Schedule{DeliverAt: req.DeliverAt, Multicast: &later})
+		return err
+	}
+	remotes := make(map[string][]string)
+	for _, u := range allowed {
+		if recorded && !isForwarded(ctx) {
+			gHistory.record(userConversation(u), req.Event)
+		}
+		results[u] = pub.deliver(u, req.UserSelector[u], req.Event)
+		for _, address := range pub.locate(ctx, u) {
+			remotes[address] = append(remotes[address], u)
+		}
+	}
+	for address, users := range remotes {
+		for u, err := range pub.forwardMulticast(ctx, address, users, req) {
+			results[u] = mergeDelivery(results[u], err)
+		}
+	}
+	for u, err := range results {
+		if err = pub.keep(ctx, u, req.UserSelector[u], req.Event, err); err != nil {
+			res.UserErrcode[u] = errorCode(err)
+			notifyUndeliverable(ctx, u, req.Event, err)
+		}
+	}
+	return nil
+}
+
+// Publish TODO
+func (pub *Publisher) Publish(ctx context.Context, req *proto.PublishRequest, res *proto.PublishResponse) error {
+	if err := validateTopic(req.Topic); err != nil {
+		return err
+	}
+	if err := validateEvent(UniqueID{}, req.Event); err != nil {
+		return err
+	}
+	account, err := caller(ctx)
+	if err != nil {
+		return err
+	}
+	if err := gPolicy.AllowTopic(account, req.Event, req.Topic); err != nil {
+		return err
+	}
+	stamp(ctx, req.Event)
+	req.Event.Topic = req.Topic
+	if err := gTopics.Publish(req.Topic, req.Event); err != nil {
+		return errorInternal("publish to topic %q: %v", req.Topic, err)
 	}
 	return nil
 }
+
+// forwardMulticast publishes to users connected to the remote node at address, and returns the result of each user
+func (pub *Publisher) forwardMulticast(ctx context.Context, address string, users []string, req *proto.MulticastRequest) map[string]error {
+	selectors := make(map[string]*proto.Selector)
+	for _, u := range users {
+		if s, ok := req.UserSelector[u]; ok {
+			selectors[u] = s
+		}
+	}
+	results := make(map[string]error, len(users))
+	ctx, node, opt := forward(ctx, address)
+	rsp, err := node.Multicast(ctx, &proto.MulticastRequest{
+		UserId:       users,
+		Event:        req.Event,
+		UserSelector: selectors,
+	}, opt)
+	if err != nil {
+		logger.Errorf("forward multicast to %v error: %v", address, err)
+	}
+	for _, u := range users {
+		switch {
+		case err != nil:
+			results[u] = err
+		case rsp.UserErrcode[u] != proto.ErrorCode_ERR_UNSPECIFIED:
+			results[u] = errors.InternalServerError(rsp.UserErrcode[u].String(), "forwarded to %v", address)
+		default:
+			results[u] = nil
+		}
+	}
+	return results
+}
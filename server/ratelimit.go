@@ -0,0 +1,292 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aclisp/sims/proto"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/metadata"
+	"github.com/micro/go-micro/v2/server"
+)
+
+// Limit is a token bucket, which allows Rate requests per second on average, and Burst at once
+type Limit struct {
+	Rate  float64
+	Burst float64
+}
+
+// ParseLimit parses a Limit of "rate[:burst]". The burst defaults to the rate, and at least one.
+func ParseLimit(s string) (Limit, error) {
+	parts := strings.SplitN(s, ":", 2)
+	rate, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil || rate <= 0 {
+		return Limit{}, fmt.Errorf("invalid rate %q", parts[0])
+	}
+	burst := math.Max(rate, 1)
+	if len(parts) == 2 {
+		burst, err = strconv.ParseFloat(parts[1], 64)
+		if err != nil || burst < 1 {
+			return Limit{}, fmt.Errorf("invalid burst %q", parts[1])
+		}
+	}
+	return Limit{Rate: rate, Burst: burst}, nil
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Limiter keeps a token bucket of the Limit for each key, e.g. a user. A nil Limiter allows everything.
+type Limiter struct {
+	limit Limit
+
+	lock    sync.Mutex
+	buckets map[string]*bucket
+}
+
+// NewLimiter creates a Limiter of limit
+func NewLimiter(limit Limit) *Limiter {
+	return &Limiter{
+		limit:   limit,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// Allow takes a token from the bucket of key, and tells if there was one
+func (l *Limiter) Allow(key string) bool {
+	if l == nil {
+		return true
+	}
+	now := time.Now()
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.limit.Burst, last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(l.limit.Burst, b.tokens+now.Sub(b.last).Seconds()*l.limit.Rate)
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// purge drops the buckets which are full again, as if they were never used
+func (l *Limiter) purge() {
+	if l == nil {
+		return
+	}
+	now := time.Now()
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.limit.Rate >= l.limit.Burst {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// RateLimits are the limits on publishing and connecting. A nil limiter is unlimited.
+type RateLimits struct {
+	Publisher *Limiter // per publishing identity
+	Recipient *Limiter // per user receiving unicast or multicast
+	Connect   *Limiter // per user connecting
+}
+
+func errorRateLimited(format string, a ...interface{}) error {
+	return errors.New(proto.ErrorCode_ERR_RATE_LIMITED.String(), fmt.Sprintf(format, a...), 429)
+}
+
+// ParseProxies parses comma separated IPs or CIDRs of the proxies trusted to forward the client address
+func ParseProxies(s string) ([]*net.IPNet, error) {
+	var proxies []*net.IPNet
+	for _, p := range strings.Split(s, ",") {
+		p = strings.TrimSpace(p)
+		if !strings.Contains(p, "/") {
+			if ip := net.ParseIP(p); ip == nil {
+				return nil, fmt.Errorf("invalid proxy %q", p)
+			} else if ip.To4() != nil {
+				p += "/32"
+			} else {
+				p += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy %q", p)
+		}
+		proxies = append(proxies, network)
+	}
+	return proxies, nil
+}
+
+// trustedProxy tells if addr is one of trustedProxies
+func trustedProxy(addr string) bool {
+	ip := net.ParseIP(strings.TrimSpace(addr))
+	if ip == nil {
+		return false
+	}
+	for _, network := range trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientAddress returns the address of the client. X-Forwarded-For is only taken from trusted proxies, from the
+// right, since any client may send one: it is the rightmost address not of a trusted proxy, or the peer.
+func clientAddress(ctx context.Context) string {
+	remote, _ := metadata.Get(ctx, "Remote")
+	if host, _, err := net.SplitHostPort(remote); err == nil {
+		remote = host
+	}
+	addrs := []string{remote}
+	if ips, ok := metadata.Get(ctx, "X-Forwarded-For"); ok {
+		addrs = append(strings.Split(ips, ","), remote)
+	}
+	i := len(addrs) - 1
+	for i > 0 && trustedProxy(addrs[i]) {
+		i--
+	}
+	return strings.TrimSpace(addrs[i])
+}
+
+// publisherKey identifies who publishes: the authenticated account, or else the client address
+func publisherKey(ctx context.Context) (string, error) {
+	if o, ok := originOf(ctx); ok {
+		return o.publisher, nil
+	}
+	account, err := caller(ctx)
+	if err != nil {
+		return "", err
+	}
+	if account != nil {
+		return "account/" + account.ID, nil
+	}
+	return "ip/" + clientAddress(ctx), nil
+}
+
+func (l *RateLimits) allowPublisher(ctx context.Context) error {
+	if l == nil || l.Publisher == nil {
+		return nil
+	}
+	key, err := publisherKey(ctx)
+	if err != nil {
+		return err
+	}
+	if !l.Publisher.Allow(key) {
+		return errorRateLimited("%v publishes too fast", key)
+	}
+	return nil
+}
+
+func (l *RateLimits) allowRecipient(userID string) error {
+	if l == nil || l.Recipient.Allow(userID) {
+		return nil
+	}
+	return errorRateLimited("%v receives too fast", userID)
+}
+
+func (l *RateLimits) allowConnect(ctx context.Context, header *proto.Header) error {
+	if l == nil || l.Connect == nil {
+		return nil
+	}
+	uid, err := uniqueIDFromHeader(ctx, header)
+	if err != nil {
+		// left to the handler
+		return nil
+	}
+	if !l.Connect.Allow(uid.UserID) {
+		return errorRateLimited("%v connects too fast", uid.UserID)
+	}
+	return nil
+}
+
+// limit checks the request of a handler against the limits
+func (l *RateLimits) limit(ctx context.Context, body interface{}) error {
+	switch req := body.(type) {
+	case *proto.ConnectRequest:
+		return l.allowConnect(ctx, req.Header)
+	case *proto.UnicastRequest:
+		if err := l.allowPublisher(ctx); err != nil {
+			return err
+		}
+		return l.allowRecipient(req.UserId)
+	case *proto.MulticastRequest, *proto.PublishRequest, *proto.BroadcastRequest:
+		return l.allowPublisher(ctx)
+	}
+	return nil
+}
+
+// limitRecipients drops the users of req which receive too fast, and returns their ErrorCode
+func (l *RateLimits) limitRecipients(req *proto.MulticastRequest) map[string]proto.ErrorCode {
+	if l == nil || l.Recipient == nil {
+		return nil
+	}
+	limited := make(map[string]proto.ErrorCode)
+	allowed := req.UserId[:0]
+	for _, u := range req.UserId {
+		if l.Recipient.Allow(u) {
+			allowed = append(allowed, u)
+		} else {
+			limited[u] = proto.ErrorCode_ERR_RATE_LIMITED
+		}
+	}
+	req.UserId = allowed
+	return limited
+}
+
+func (l *RateLimits) purge() {
+	if l == nil {
+		return
+	}
+	l.Publisher.purge()
+	l.Recipient.purge()
+	l.Connect.purge()
+}
+
+// rateLimit is a server.HandlerWrapper enforcing gLimits on any handler, by the type of its request.
+// The requests forwarded by other nodes are limited there already, but only those verified by verifyForwarded,
+// which wraps it, are exempted: a client sending the forwarded mark is limited as any other.
+func rateLimit(fn server.HandlerFunc) server.HandlerFunc {
+	return func(ctx context.Context, req server.Request, rsp interface{}) error {
+		if gLimits == nil || isForwarded(ctx) {
+			return fn(ctx, req, rsp)
+		}
+		if err := gLimits.limit(ctx, req.Body()); err != nil {
+			return err
+		}
+		multicast, ok := req.Body().(*proto.MulticastRequest)
+		if !ok || len(multicast.UserId) == 0 {
+			return fn(ctx, req, rsp)
+		}
+		limited := gLimits.limitRecipients(multicast)
+		res, _ := rsp.(*proto.MulticastResponse)
+		if len(multicast.UserId) > 0 {
+			if err := fn(ctx, req, rsp); err != nil {
+				return err
+			}
+		}
+		if res != nil && len(limited) > 0 {
+			if res.UserErrcode == nil {
+				res.UserErrcode = make(map[string]proto.ErrorCode, len(limited))
+			}
+			for u, code := range limited {
+				res.UserErrcode[u] = code
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aclisp/sims/proto"
+	"github.com/micro/go-micro/v2/metadata"
+	"github.com/micro/go-micro/v2/registry"
+	"github.com/micro/go-micro/v2/registry/memory"
+	"github.com/micro/go-micro/v2/server"
+)
+
+func TestParseLimit(t *testing.T) {
+	for s, want := range map[string]Limit{
+		"10":     {Rate: 10, Burst: 10},
+		"0.2":    {Rate: 0.2, Burst: 1},
+		"0.2:5":  {Rate: 0.2, Burst: 5},
+		"100:50": {Rate: 100, Burst: 50},
+	} {
+		got, err := ParseLimit(s)
+		if err != nil || got != want {
+			t.Errorf("ParseLimit(%q) = %v, %v, want %v", s, got, err, want)
+		}
+	}
+	for _, s := range []string{"", "0", "-1", "x", "1:0", "1:x"} {
+		if _, err := ParseLimit(s); err == nil {
+			t.Errorf("ParseLimit(%q) succeeds, want error", s)
+		}
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	l := NewLimiter(Limit{Rate: 10, Burst: 2})
+	for i := 0; i < 2; i++ {
+		if !l.Allow("a") {
+			t.Fatalf("request %v within the burst is limited", i)
+		}
+	}
+	if l.Allow("a") {
+		t.Fatal("request over the burst is allowed")
+	}
+	if !l.Allow("b") {
+		t.Fatal("another key is limited")
+	}
+	time.Sleep(150 * time.Millisecond)
+	if !l.Allow("a") {
+		t.Fatal("request after refilling is limited")
+	}
+
+	time.Sleep(250 * time.Millisecond)
+	l.purge()
+	if n := len(l.buckets); n != 0 {
+		t.Fatalf("%v buckets after they are full, want 0", n)
+	}
+
+	var unlimited *Limiter
+	if !unlimited.Allow("a") {
+		t.Fatal("nil Limiter limits")
+	}
+}
+
+func TestPublisherKey(t *testing.T) {
+	proxies, err := ParseProxies("10.0.0.0/8, 192.168.1.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	trustedProxies = proxies
+	defer func() { trustedProxies = nil }()
+
+	request := func(remote, xff string) context.Context {
+		md := metadata.Metadata{"Remote": remote}
+		if xff != "" {
+			md["X-Forwarded-For"] = xff
+		}
+		return metadata.NewContext(context.Background(), md)
+	}
+	for _, c := range []struct {
+		remote, xff, want string
+	}{
+		// the gateway appends the client it sees, after whatever the client sends
+		{"10.0.0.2:5000", "1.1.1.1", "ip/1.1.1.1"},
+		{"10.0.0.2:5000", "6.6.6.6, 1.1.1.1", "ip/1.1.1.1"},
+		{"10.0.0.2:5000", "6.6.6.6, 1.1.1.1, 192.168.1.1", "ip/1.1.1.1"},
+		// a client connecting directly may not claim another address
+		{"2.2.2.2:5000", "1.1.1.1", "ip/2.2.2.2"},
+		{"2.2.2.2:5000", "", "ip/2.2.2.2"},
+		{"10.0.0.2:5000", "", "ip/10.0.0.2"},
+	} {
+		key, err := publisherKey(request(c.remote, c.xff))
+		if err != nil || key != c.want {
+			t.Errorf("publisher from %v by %q is %v, %v, want %v", c.remote, c.xff, key, err, c.want)
+		}
+	}
+
+	for _, s := range []string{"x", "10.0.0.0/33", "1.1.1.1,"} {
+		if _, err := ParseProxies(s); err == nil {
+			t.Errorf("ParseProxies(%q) succeeds, want error", s)
+		}
+	}
+}
+
+type testRequest struct {
+	server.Request
+	body interface{}
+}
+
+func (r testRequest) Body() interface{} {
+	return r.body
+}
+
+func TestRateLimitForwarded(t *testing.T) {
+	r := memory.NewRegistry()
+	if err := r.Register(&registry.Service{
+		Name:  MicroServiceName,
+		Nodes: []*registry.Node{{Id: "node", Address: "10.0.0.1:9000"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	gNodes = NewNodes(r)
+	gLimits = &RateLimits{Publisher: NewLimiter(Limit{Rate: 1, Burst: 1})}
+	defer func() { gNodes, gLimits = nil, nil }()
+
+	handler := verifyForwarded(rateLimit(func(ctx context.Context, req server.Request, rsp interface{}) error {
+		return nil
+	}))
+	request := func(remote string) context.Context {
+		return metadata.NewContext(context.Background(), metadata.Metadata{"Remote": remote, "Sims-Forwarded": "10.0.0.1:9000"})
+	}
+	req := testRequest{body: &proto.UnicastRequest{UserId: "a"}}
+	// a client spoofing the mark is limited
+	if err := handler(request("2.2.2.2:5000"), req, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := handler(request("2.2.2.2:5000"), req, nil); errorCode(err) != proto.ErrorCode_ERR_RATE_LIMITED {
+		t.Fatalf("spoofed request: %v, want %v", err, proto.ErrorCode_ERR_RATE_LIMITED)
+	}
+	// while the node is not
+	for i := 0; i < 3; i++ {
+		if err := handler(request("10.0.0.1:5000"), req, nil); err != nil {
+			t.Fatalf("forwarded request %v: %v", i, err)
+		}
+	}
+}
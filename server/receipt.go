@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aclisp/sims/proto"
+	pb "github.com/golang/protobuf/proto"
+	"github.com/micro/go-micro/v2/broker"
+	"github.com/micro/go-micro/v2/logger"
+)
+
+const (
+	// receiptTopic is the broker topic where every node sends the receipts of the events delivered by it.
+	// Publishers may subscribe to it directly, instead of Publisher.Receipts.
+	receiptTopic = MicroServiceName + ".receipt"
+	// receiptWatchBuffer is the number of receipts buffered for each watcher
+	receiptWatchBuffer = 256
+)
+
+// Receipts routes the receipts of events from the node delivering them to the publishers watching on any node
+type Receipts struct {
+	broker broker.Broker
+
+	lock     sync.Mutex
+	watchers map[string]map[chan *proto.Receipt]bool // publisher => watchers
+}
+
+// NewReceipts creates the receipt service distributing receipts by b
+func NewReceipts(b broker.Broker) *Receipts {
+	return &Receipts{
+		broker:   b,
+		watchers: make(map[string]map[chan *proto.Receipt]bool),
+	}
+}
+
+// Start listens to the receipts from all nodes
+func (r *Receipts) Start() error {
+	_, err := r.broker.Subscribe(receiptTopic, r.handle)
+	return err
+}
+
+// send announces the status of event received by uid to its publisher, if requested
+func (r *Receipts) send(uid UniqueID, event *proto.Event, status proto.ReceiptStatus) {
+	if r == nil || event.ReceiptTo == "" {
+		return
+	}
+	body, err := pb.Marshal(&proto.Receipt{
+		EventId:   event.Id,
+		UserId:    uid.UserID,
+		DeviceId:  uid.DeviceID,
+		Status:    status,
+		Timestamp: time.Now().UnixNano() / int64(time.Millisecond),
+		Publisher: event.ReceiptTo,
+	})
+	if err != nil {
+		logger.Errorf("[%v] encode receipt error: %v", uid, err)
+		return
+	}
+	if err := r.broker.Publish(receiptTopic, &broker.Message{
+		Header: map[string]string{"Content-Type": "application/protobuf"},
+		Body:   body,
+	}); err != nil {
+		logger.Errorf("[%v] publish receipt error: %v", uid, err)
+	}
+}
+
+// handle passes a receipt to the local watchers of its publisher
+func (r *Receipts) handle(e broker.Event) error {
+	receipt := new(proto.Receipt)
+	if err := pb.Unmarshal(e.Message().Body, receipt); err != nil {
+		logger.Errorf("decode receipt error: %v", err)
+		return err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for watcher := range r.watchers[receipt.Publisher] {
+		select {
+		case watcher <- receipt:
+		default:
+			logger.Warnf("[%v] receipt watcher is too slow, receipt dropped", receipt.Publisher)
+		}
+	}
+	return nil
+}
+
+func (r *Receipts) watch(publisher string) chan *proto.Receipt {
+	watcher := make(chan *proto.Receipt, receiptWatchBuffer)
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.watchers[publisher] == nil {
+		r.watchers[publisher] = make(map[chan *proto.Receipt]bool)
+	}
+	r.watchers[publisher][watcher] = true
+	return watcher
+}
+
+func (r *Receipts) unwatch(publisher string, watcher chan *proto.Receipt) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.watchers[publisher], watcher)
+	if len(r.watchers[publisher]) == 0 {
+		delete(r.watchers, publisher)
+	}
+}
+
+// Receipts TODO
+func (pub *Publisher) Receipts(ctx context.Context, req *proto.ReceiptsRequest, stream proto.Publisher_ReceiptsStream) error {
+	publisher, err := publisherKey(ctx)
+	if err != nil {
+		return err
+	}
+	watcher := gReceipts.watch(publisher)
+	defer gReceipts.unwatch(publisher, watcher)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case receipt := <-watcher:
+			if err := stream.Send(receipt); err != nil {
+				return err
+			}
+		}
+	}
+}
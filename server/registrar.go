@@ -3,24 +3,91 @@ package main
 import (
 	"context"
 	"errors"
+	"hash/fnv"
 	"sort"
 	"sync"
 	"time"
 
 	"github.com/aclisp/sims/proto"
 	"github.com/micro/go-micro/v2/logger"
+	"go.uber.org/atomic"
 )
 
+// registrarShards is the number of shards of the channels, which must be a power of 2
+const registrarShards = 64
+
+// shard is a part of the channels, whose user_id hash to it
+type shard struct {
+	lock     sync.RWMutex
+	channels map[UniqueID]*Channel
+	devices  map[string]map[string]*Channel // user_id -> device_id -> channel
+}
+
 // Registrar TODO
 type Registrar struct {
-	lock     sync.Mutex
-	channels map[UniqueID]*Channel
+	shards    [registrarShards]shard
+	wheel     *timingWheel // schedules the expiry of channels
+	size      atomic.Int64 // the number of channels
+	queueSize int
+	draining  atomic.Bool // the node is shutting down, refusing new channels
+	publisher *Publisher  // sends the events from Streamer.Session
 }
 
 // NewRegistrar TODO
 func NewRegistrar() *Registrar {
-	return &Registrar{
-		channels: make(map[UniqueID]*Channel),
+	reg := &Registrar{
+		wheel:     newTimingWheel(HousekeepInterval, time.Now()),
+		queueSize: DefaultEventQueueSize,
+	}
+	for i := range reg.shards {
+		reg.shards[i].channels = make(map[UniqueID]*Channel)
+		reg.shards[i].devices = make(map[string]map[string]*Channel)
+	}
+	return reg
+}
+
+// shardIndex returns the index of the shard of a user, so that all devices of the user are in one shard
+func shardIndex(userID string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(userID))
+	return h.Sum32() & (registrarShards - 1)
+}
+
+func (reg *Registrar) shard(userID string) *shard {
+	return &reg.shards[shardIndex(userID)]
+}
+
+// add indexes channel by uid, must be called with lock held
+func (s *shard) add(uid UniqueID, channel *Channel) {
+	s.channels[uid] = channel
+	devices, ok := s.devices[uid.UserID]
+	if !ok {
+		devices = make(map[string]*Channel)
+		s.devices[uid.UserID] = devices
+	}
+	devices[uid.DeviceID] = channel
+}
+
+// remove drops the index of uid, must be called with lock held
+func (s *shard) remove(uid UniqueID) {
+	delete(s.channels, uid)
+	if devices, ok := s.devices[uid.UserID]; ok {
+		delete(devices, uid.DeviceID)
+		if len(devices) == 0 {
+			delete(s.devices, uid.UserID)
+		}
+	}
+}
+
+// each calls f on every channel, with the lock of its shard held
+func (reg *Registrar) each(f func(uid UniqueID, channel *Channel)) {
+	for i := range reg.shards {
+		s := &reg.shards[i]
+		s.lock.RLock()
+		for uid, channel := range s.channels {
+			f(uid, channel)
+		}
+		s.lock.RUnlock()
 	}
 }
 
@@ -29,17 +96,17 @@ func (reg *Registrar) ListChannels() []*proto.Channel {
 	type ch struct {
 		UniqueID
 		*Channel
+		LastHeartbeat time.Time
 	}
 
-	reg.lock.Lock()
-	ca := make([]ch, 0, len(reg.channels))
-	for uid, channel := range reg.channels {
+	ca := make([]ch, 0, reg.size.Load())
+	reg.each(func(uid UniqueID, channel *Channel) {
 		ca = append(ca, ch{
-			UniqueID: uid,
-			Channel:  channel,
+			UniqueID:      uid,
+			Channel:       channel,
+			LastHeartbeat: channel.LastHeartbeat,
 		})
-	}
-	reg.lock.Unlock()
+	})
 
 	sort.Slice(ca, func(i, j int) bool { return ca[i].LastHeartbeat.After(ca[j].LastHeartbeat) })
 
@@ -47,6 +114,8 @@ func (reg *Registrar) ListChannels() []*proto.Channel {
 	for i := range ca {
 		cb[i] = &proto.Channel{
 			UserId:        ca[i].UserID,
+			DeviceId:      ca[i].DeviceID,
+			UserAgent:     ca[i].UserAgent,
 			Birth:         ca[i].Birth.Format(time.RFC3339),
 			LastHeartbeat: ca[i].LastHeartbeat.Format(time.RFC3339),
 			Active:        int32(ca[i].Active.Load()),
@@ -56,93 +125,389 @@ func (reg *Registrar) ListChannels() []*proto.Channel {
 }
 
 func (reg *Registrar) housekeep() {
-	reg.lock.Lock()
-	defer reg.lock.Unlock()
-
-	deadline := time.Now().Add(-ChannelInactivity)
-	for uid, channel := range reg.channels {
-		if channel.LastHeartbeat.Before(deadline) {
-			close(channel.EventQueue)
-			delete(reg.channels, uid)
+	reg.expire(time.Now())
+}
+
+// expire closes the channels inactive at now. Only the channels due on the wheel are checked,
+// and those alive are scheduled again by their last heartbeat.
+func (reg *Registrar) expire(now time.Time) {
+	var due [registrarShards][]wheelEntry
+	for _, e := range reg.wheel.advance(now) {
+		due[e.channel.shard] = append(due[e.channel.shard], e)
+	}
+
+	var alive, expired []wheelEntry
+	for i := range due {
+		if len(due[i]) == 0 {
+			continue
+		}
+		s := &reg.shards[i]
+		s.lock.Lock()
+		for _, e := range due[i] {
+			if e.channel.closed.Load() || s.channels[e.channel.uid] != e.channel {
+				// removed, e.g. kicked but not yet closed, and the channel created again for the uid is scheduled by itself
+				continue
+			}
+			if deadline := e.channel.LastHeartbeat.Add(e.channel.inactivity()); deadline.After(now) {
+				e.at = reg.wheel.tickOf(deadline)
+				alive = append(alive, e)
+				continue
+			}
+			e.channel.closeQueue()
+			s.remove(e.channel.uid)
+			reg.size.Dec()
+			expired = append(expired, e)
 		}
+		s.lock.Unlock()
+	}
+	reg.wheel.reschedule(alive)
+
+	metricEvictions.Add(uint64(len(expired)))
+	for _, e := range expired {
+		reg.release(e.channel.uid, e.channel)
+		gHooks.notify(proto.HookEvent_HOOK_TIMED_OUT, e.channel.uid, "", "")
 	}
 }
 
 func (reg *Registrar) close() {
-	reg.lock.Lock()
-	defer reg.lock.Unlock()
+	closed := make(map[UniqueID]*Channel, reg.size.Load())
+	for i := range reg.shards {
+		s := &reg.shards[i]
+		s.lock.Lock()
+		for uid, channel := range s.channels {
+			channel.closeQueue()
+			s.remove(uid)
+			reg.size.Dec()
+			closed[uid] = channel
+		}
+		s.lock.Unlock()
+	}
+
+	metricDisconnects.Add(uint64(len(closed)), "shutdown")
+	for uid, channel := range closed {
+		reg.release(uid, channel)
+	}
+}
+
+// release cleans up a closed channel: withdraws its location and keeps its undelivered events in the mailbox
+func (reg *Registrar) release(uid UniqueID, channel *Channel) {
+	reg.unlocate(uid)
+	if gTopics != nil {
+		gTopics.UnsubscribeAll(uid)
+	}
+	if gPresence != nil {
+		gPresence.Changed(uid.UserID)
+	}
+	if gMailbox == nil {
+		return
+	}
+	for event := range channel.Urgent {
+		reg.keep(uid, channel, event)
+	}
+	for event := range channel.EventQueue {
+		reg.keep(uid, channel, event)
+	}
+}
+
+// keep puts an undelivered event into the mailbox, if enabled. Kicks and reconnects are only meant for the
+// closed channel, whose node they are about.
+func (reg *Registrar) keep(uid UniqueID, channel *Channel, event *proto.Event) bool {
+	if gMailbox == nil {
+		return false
+	}
+	switch event.Type {
+	case proto.EventType_EVT_HEARTBEAT, proto.EventType_EVT_KICKED, proto.EventType_EVT_RECONNECT:
+		return false
+	}
+	if err := gMailbox.Put(uid, nil, event); err != nil {
+		logger.Errorf("[%v] keep event in mailbox error: %v", uid, err)
+		return false
+	}
+	channel.Spilled.Store(true)
+	channel.collapse(event)
+	return true
+}
+
+// eventSender is where the events of a channel are sent to, e.g. Streamer.Events
+type eventSender interface {
+	Send(*proto.Event) error
+}
+
+// send sends event to the stream. Events except heartbeats are sequenced, and kept until acknowledged.
+// Events past their TTL, or superseded by a later one of the same collapse_key, are dropped.
+func (reg *Registrar) send(channel *Channel, stream eventSender, event *proto.Event) error {
+	if event.Type == proto.EventType_EVT_HEARTBEAT {
+		return stream.Send(event)
+	}
+	if channel.kicked.Load() {
+		// the events after EVT_KICKED are left for the next channel
+		reg.keep(channel.uid, channel, event)
+		return nil
+	}
+	if expired(event) || channel.superseded(event) {
+		return nil
+	}
+	event = channel.sequence(event)
+	// record it even if failed, so that it can be redelivered by resuming
+	channel.sent(event)
+	metricQueueWait.Observe(since(event.Timestamp))
+	err := reg.deliver(channel, stream, event)
+	// from publishing until sent
+	traceSpan("sims.send", channel.uid, event, time.Unix(0, event.Timestamp*int64(time.Millisecond)), err)
+	if err == nil && event.Type == proto.EventType_EVT_KICKED {
+		// which ends the stream
+		reg.closeKicked(channel)
+	}
+	return err
+}
+
+// deliver sends a sequenced event to the stream, and tells its publisher if a receipt is requested
+func (reg *Registrar) deliver(channel *Channel, stream eventSender, event *proto.Event) error {
+	defer func(start time.Time) { metricSendLatency.Observe(time.Since(start).Seconds()) }(time.Now())
+	if event.ReceiptTo == "" {
+		return stream.Send(event)
+	}
+	// the publisher is not told to the client
+	sent := *event
+	sent.ReceiptTo = ""
+	if err := stream.Send(&sent); err != nil {
+		return err
+	}
+	gReceipts.send(channel.uid, event, proto.ReceiptStatus_RECEIPT_DELIVERED)
+	return nil
+}
+
+// read tells the publishers of the events acknowledged by channel, if receipts are requested
+func (reg *Registrar) read(channel *Channel, events []*proto.Event) {
+	for _, event := range events {
+		gReceipts.send(channel.uid, event, proto.ReceiptStatus_RECEIPT_READ)
+	}
+}
+
+// sendUrgent sends the high priority events queued
+func (reg *Registrar) sendUrgent(channel *Channel, stream eventSender) error {
+	for {
+		select {
+		case event, ok := <-channel.Urgent:
+			if !ok {
+				return nil
+			}
+			if err := reg.send(channel, stream, event); err != nil {
+				return err
+			}
+		default:
+			return nil
+		}
+	}
+}
+
+// replay sends the events kept in the mailbox to the stream
+func (reg *Registrar) replay(uid UniqueID, channel *Channel, stream eventSender) error {
+	if gMailbox == nil {
+		return nil
+	}
+	channel.Spilled.Store(false)
+	events, err := gMailbox.Take(uid, channel)
+	if err != nil {
+		logger.Errorf("[%v] take events from mailbox error: %v", uid, err)
+	}
+	for i, event := range events {
+		if err := reg.send(channel, stream, event); err != nil {
+			for _, event := range events[i+1:] {
+				reg.keep(uid, channel, event)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// locate publishes the location of uid to the cluster
+func (reg *Registrar) locate(uid UniqueID) {
+	if gLocator == nil {
+		return
+	}
+	if err := gLocator.Register(uid, gAddress); err != nil {
+		logger.Errorf("[%v] register location error: %v", uid, err)
+	}
+}
 
-	for _, channel := range reg.channels {
-		close(channel.EventQueue)
+// unlocate withdraws the location of uid from the cluster
+func (reg *Registrar) unlocate(uid UniqueID) {
+	if gLocator == nil {
+		return
+	}
+	if err := gLocator.Deregister(uid, gAddress); err != nil {
+		logger.Errorf("[%v] deregister location error: %v", uid, err)
 	}
 }
 
 func (reg *Registrar) findEventQueue(uid UniqueID) chan *proto.Event {
-	reg.lock.Lock()
-	defer reg.lock.Unlock()
-	if channel, ok := reg.channels[uid]; ok {
+	if channel := reg.findChannel(uid); channel != nil {
 		return channel.EventQueue
 	}
 	return nil
 }
 
+// findChannels returns the channels of every device of a user, which are matched by selector
+func (reg *Registrar) findChannels(userID string, selector *proto.Selector) map[UniqueID]*Channel {
+	s := reg.shard(userID)
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	devices := s.devices[userID]
+	channels := make(map[UniqueID]*Channel, len(devices))
+	for deviceID, channel := range devices {
+		if matchSelector(selector, channel) {
+			channels[UniqueID{UserID: userID, DeviceID: deviceID}] = channel
+		}
+	}
+	return channels
+}
+
 func (reg *Registrar) findChannel(uid UniqueID) *Channel {
-	reg.lock.Lock()
-	defer reg.lock.Unlock()
-	return reg.channels[uid]
+	s := reg.shard(uid.UserID)
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.channels[uid]
 }
 
-func (reg *Registrar) heartbeat(uid UniqueID) {
-	reg.lock.Lock()
-	defer reg.lock.Unlock()
-	if channel, ok := reg.channels[uid]; ok {
-		channel.LastHeartbeat = time.Now()
+// alive keeps uid alive, and refreshes its location if needed
+func (reg *Registrar) alive(uid UniqueID) {
+	if reg.heartbeat(uid) {
+		reg.locate(uid)
 	}
 }
 
-func (reg *Registrar) createEventQueue(uid UniqueID) {
-	reg.lock.Lock()
-	defer reg.lock.Unlock()
-	if _, ok := reg.channels[uid]; ok {
-		return
+// heartbeat keeps uid alive, and reports whether its location should be refreshed
+func (reg *Registrar) heartbeat(uid UniqueID) (relocate bool) {
+	s := reg.shard(uid.UserID)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if channel, ok := s.channels[uid]; ok {
+		now := time.Now()
+		channel.LastHeartbeat = now
+		if now.Sub(channel.Located) > LocationTTL/2 {
+			channel.Located = now
+			return true
+		}
 	}
-	channel := &Channel{
-		EventQueue:    make(chan *proto.Event),
-		Birth:         time.Now(),
-		LastHeartbeat: time.Now(),
+	return false
+}
+
+// createEventQueue creates the channel of uid, and reports whether it is new
+// The ping interval of an existing channel is renegotiated.
+func (reg *Registrar) createEventQueue(uid UniqueID, userAgent string, interval time.Duration) (channel *Channel, created bool) {
+	i := shardIndex(uid.UserID)
+	s := &reg.shards[i]
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if channel, ok := s.channels[uid]; ok {
+		channel.Interval.Store(int64(interval))
+		return channel, false
 	}
-	reg.channels[uid] = channel
+	now := time.Now()
+	channel = &Channel{
+		EventQueue:    make(chan *proto.Event, reg.queueSize),
+		Urgent:        make(chan *proto.Event, UrgentQueueSize),
+		UserAgent:     userAgent,
+		Birth:         now,
+		LastHeartbeat: now,
+		Located:       now,
+		uid:           uid,
+		shard:         i,
+	}
+	channel.Interval.Store(int64(interval))
+	s.add(uid, channel)
+	reg.size.Inc()
+	reg.wheel.schedule(channel, now.Add(channel.inactivity()))
+	return channel, true
 }
 
-func (reg *Registrar) deleteEventQueue(uid UniqueID) {
-	reg.lock.Lock()
-	defer reg.lock.Unlock()
-	if channel, ok := reg.channels[uid]; ok {
-		close(channel.EventQueue)
-		delete(reg.channels, uid)
+// removeEventQueue drops the channel of uid from the registrar, but leaves its queue open
+func (reg *Registrar) removeEventQueue(uid UniqueID) *Channel {
+	s := reg.shard(uid.UserID)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if channel, ok := s.channels[uid]; ok {
+		s.remove(uid)
+		reg.size.Dec()
+		return channel
 	}
+	return nil
+}
+
+func (reg *Registrar) deleteEventQueue(uid UniqueID) *Channel {
+	s := reg.shard(uid.UserID)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if channel, ok := s.channels[uid]; ok {
+		channel.closeQueue()
+		s.remove(uid)
+		reg.size.Dec()
+		return channel
+	}
+	return nil
 }
 
 // Heartbeat TODO
 func (reg *Registrar) Heartbeat(ctx context.Context, req *proto.HeartbeatRequest, res *proto.HeartbeatResponse) error {
-	uid, err := uniqueIDFromHeader(req.Header)
+	uid, err := uniqueIDFromHeader(ctx, req.Header)
+	if err != nil {
+		return err
+	}
+
+	if reg.findChannel(uid) == nil {
+		return errorNotRegistered(uid)
+	}
+	reg.alive(uid)
+	return nil
+}
+
+// Ack TODO
+func (reg *Registrar) Ack(ctx context.Context, req *proto.AckRequest, res *proto.AckResponse) error {
+	uid, err := uniqueIDFromHeader(ctx, req.Header)
 	if err != nil {
 		return err
 	}
 
-	events := reg.findEventQueue(uid)
-	if events == nil {
+	channel := reg.findChannel(uid)
+	if channel == nil {
 		return errorNotRegistered(uid)
 	}
+	reg.read(channel, channel.ack(req.Seq))
+	return nil
+}
+
+// Subscribe TODO
+func (reg *Registrar) Subscribe(ctx context.Context, req *proto.SubscribeRequest, res *proto.SubscribeResponse) error {
+	uid, err := uniqueIDFromHeader(ctx, req.Header)
+	if err != nil {
+		return err
+	}
+	if err := validateTopic(req.Topic); err != nil {
+		return err
+	}
 
-	reg.heartbeat(uid)
+	if reg.findChannel(uid) == nil {
+		return errorNotRegistered(uid)
+	}
+	if err := gTopics.Subscribe(uid, req.Topic); err != nil {
+		return errorInternal("subscribe %v to topic %q: %v", uid, req.Topic, err)
+	}
+	return nil
+}
 
-	event := &proto.Event{Type: proto.EventType_EVT_HEARTBEAT}
-	select {
-	case events <- event:
-	default:
-		return errorNoConsumer(uid)
+// Unsubscribe TODO
+func (reg *Registrar) Unsubscribe(ctx context.Context, req *proto.UnsubscribeRequest, res *proto.UnsubscribeResponse) error {
+	uid, err := uniqueIDFromHeader(ctx, req.Header)
+	if err != nil {
+		return err
+	}
+	if err := validateTopic(req.Topic); err != nil {
+		return err
 	}
+
+	gTopics.Unsubscribe(uid, req.Topic)
 	return nil
 }
 
@@ -150,7 +515,7 @@ func (reg *Registrar) Heartbeat(ctx context.Context, req *proto.HeartbeatRequest
 func (reg *Registrar) Events(ctx context.Context, req *proto.EventsRequest, stream proto.Streamer_EventsStream) error {
 	trace := req.GetHeader().GetRequestId()
 	// get notice message queue by user
-	uid, err := uniqueIDFromHeader(req.Header)
+	uid, err := uniqueIDFromHeader(ctx, req.Header)
 	if err != nil {
 		return err
 	}
@@ -159,43 +524,127 @@ func (reg *Registrar) Events(ctx context.Context, req *proto.EventsRequest, stre
 	if channel == nil {
 		return errorNotRegistered(uid)
 	}
+	return reg.stream(ctx, uid, trace, channel, req.ResumeFrom, stream)
+}
+
+// stream sends the events of channel to stream until either is gone
+func (reg *Registrar) stream(ctx context.Context, uid UniqueID, trace string, channel *Channel, resumeFrom uint64, stream eventSender) error {
 	channel.Active.Inc()
 	defer channel.Active.Dec()
 
 	// handle event
 	logger.Debugf("[%v %v] handling events", uid, trace)
-	for event := range channel.EventQueue {
-		if err := stream.Send(event); err != nil {
-			logger.Errorf("[%v %v] send event to stream error: %v", uid, trace, err)
+	// redeliver the unacknowledged
+	redeliver, acked := channel.resume(resumeFrom)
+	reg.read(channel, acked)
+	for _, event := range redeliver {
+		if expired(event) {
+			continue
+		}
+		if err := reg.deliver(channel, stream, event); err != nil {
+			logger.Errorf("[%v %v] redeliver event to stream error: %v", uid, trace, err)
 			return err
 		}
 	}
-	logger.Debugf("[%v %v] no more events", uid, trace)
-	return nil
+	// replay the mailbox after the older events in queue are sent
+	if len(channel.EventQueue) == 0 {
+		if err := reg.replay(uid, channel, stream); err != nil {
+			logger.Errorf("[%v %v] replay events to stream error: %v", uid, trace, err)
+			return err
+		}
+	} else {
+		channel.Spilled.Store(true)
+	}
+	// the stream keeps the channel alive by pings, until the client is gone
+	ping := time.NewTicker(channel.heartbeatInterval())
+	defer ping.Stop()
+	// the urgent queue is closed along with EventQueue, which ends the stream
+	urgent := channel.Urgent
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Debugf("[%v %v] stream is gone: %v", uid, trace, ctx.Err())
+			return nil
+		case <-ping.C:
+			if err := reg.send(channel, stream, &proto.Event{Type: proto.EventType_EVT_HEARTBEAT}); err != nil {
+				logger.Errorf("[%v %v] ping stream error: %v", uid, trace, err)
+				return err
+			}
+		case event, ok := <-urgent:
+			if !ok {
+				urgent = nil
+				continue
+			}
+			if err := reg.send(channel, stream, event); err != nil {
+				logger.Errorf("[%v %v] send urgent event to stream error: %v", uid, trace, err)
+				return err
+			}
+		case event, ok := <-channel.EventQueue:
+			// high priority events jump the queue
+			if err := reg.sendUrgent(channel, stream); err != nil {
+				logger.Errorf("[%v %v] send urgent event to stream error: %v", uid, trace, err)
+				return err
+			}
+			if !ok {
+				logger.Debugf("[%v %v] no more events", uid, trace)
+				return nil
+			}
+			if err := reg.send(channel, stream, event); err != nil {
+				logger.Errorf("[%v %v] send event to stream error: %v", uid, trace, err)
+				return err
+			}
+			if channel.Spilled.Load() && len(channel.EventQueue) == 0 {
+				if err := reg.replay(uid, channel, stream); err != nil {
+					logger.Errorf("[%v %v] replay events to stream error: %v", uid, trace, err)
+					return err
+				}
+			}
+		}
+		reg.alive(uid)
+	}
 }
 
 // Connect TODO
 func (reg *Registrar) Connect(ctx context.Context, req *proto.ConnectRequest, res *proto.ConnectResponse) error {
 	// local
-	uid, err := uniqueIDFromHeader(req.Header)
+	uid, err := uniqueIDFromHeader(ctx, req.Header)
 	if err != nil {
 		return err
 	}
-	reg.createEventQueue(uid)
-	// persist: which server box the uid belongs to?
 	if gAddress == "" {
 		return errors.New("server does not start completely")
 	}
+	if reg.draining.Load() {
+		return errorDraining()
+	}
+	if err := gAdmin.checkBan(uid.UserID); err != nil {
+		return err
+	}
+	channel, created := reg.createEventQueue(uid, req.Header.GetUserAgent(), negotiateHeartbeat(req.HeartbeatIntervalMs))
+	res.HeartbeatIntervalMs = int64(channel.heartbeatInterval() / time.Millisecond)
+	res.InactivityTimeoutMs = int64(channel.inactivity() / time.Millisecond)
+	reg.locate(uid)
+	if created {
+		metricConnects.Inc()
+		gHooks.notify(proto.HookEvent_HOOK_CONNECTED, uid, "", "")
+		if gPresence != nil {
+			gPresence.Changed(uid.UserID)
+		}
+	}
 	return nil
 }
 
 // Disconnect TODO
 func (reg *Registrar) Disconnect(ctx context.Context, req *proto.DisconnectRequest, res *proto.DisconnectResponse) error {
-	uid, err := uniqueIDFromHeader(req.Header)
+	uid, err := uniqueIDFromHeader(ctx, req.Header)
 	if err != nil {
 		return err
 	}
-	reg.deleteEventQueue(uid)
+	if channel := reg.deleteEventQueue(uid); channel != nil {
+		reg.release(uid, channel)
+		metricDisconnects.Inc("disconnect")
+		gHooks.notify(proto.HookEvent_HOOK_DISCONNECTED, uid, "", "")
+	}
 	return nil
 }
 
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aclisp/sims/proto"
+	"github.com/micro/go-micro/v2/store/memory"
+	"go.uber.org/atomic"
+)
+
+// benchChannels is the number of channels connected before a benchmark
+const benchChannels = 100000
+
+// newTestRegistrar replaces gRegistrar with one of n connected channels, and returns their UniqueID
+func newTestRegistrar(n int) []UniqueID {
+	gRegistrar = NewRegistrar()
+	gAddress = "127.0.0.1:0"
+	uids := make([]UniqueID, n)
+	for i := range uids {
+		uids[i] = UniqueID{UserID: "user" + strconv.Itoa(i)}
+		gRegistrar.createEventQueue(uids[i], "test", DefaultHeartbeatInterval)
+	}
+	return uids
+}
+
+func TestExpire(t *testing.T) {
+	uids := newTestRegistrar(2)
+	now := time.Now()
+	inactivity := InactivityHeartbeats * DefaultHeartbeatInterval
+
+	gRegistrar.expire(now.Add(inactivity / 2))
+	if n := gRegistrar.size.Load(); n != 2 {
+		t.Fatalf("%v channels after half of the inactivity, want 2", n)
+	}
+
+	// a heartbeat of the first channel
+	gRegistrar.findChannel(uids[0]).LastHeartbeat = now.Add(inactivity / 2)
+	gRegistrar.expire(now.Add(inactivity + HousekeepInterval))
+	if gRegistrar.findChannel(uids[0]) == nil {
+		t.Fatal("the channel kept alive is expired")
+	}
+	if gRegistrar.findChannel(uids[1]) != nil {
+		t.Fatal("the inactive channel is not expired")
+	}
+
+	gRegistrar.expire(now.Add(inactivity*3/2 + HousekeepInterval))
+	if n := gRegistrar.size.Load(); n != 0 {
+		t.Fatalf("%v channels after the inactivity since the last heartbeat, want 0", n)
+	}
+}
+
+func TestExpireKicked(t *testing.T) {
+	uids := newTestRegistrar(1)
+	now := time.Now()
+	inactivity := InactivityHeartbeats * DefaultHeartbeatInterval
+
+	// kicked while streaming, so it is closed after the grace, and reconnected meanwhile
+	kicked := gRegistrar.findChannel(uids[0])
+	kicked.Active.Inc()
+	gRegistrar.kick(uids[0].UserID, "", "test", 0)
+	gRegistrar.createEventQueue(uids[0], "test", DefaultHeartbeatInterval)
+	channel := gRegistrar.findChannel(uids[0])
+	channel.LastHeartbeat = now.Add(inactivity)
+
+	gRegistrar.expire(now.Add(inactivity + HousekeepInterval))
+	if gRegistrar.findChannel(uids[0]) != channel {
+		t.Fatal("the reconnected channel is expired with the kicked one")
+	}
+	if n := gRegistrar.size.Load(); n != 1 {
+		t.Fatalf("%v channels after expiring the kicked one, want 1", n)
+	}
+}
+
+func TestPushAfterDisconnect(t *testing.T) {
+	uids := newTestRegistrar(1)
+	channel := gRegistrar.findChannel(uids[0])
+	gRegistrar.deleteEventQueue(uids[0])
+	// a publisher may still hold the channel found before it is disconnected
+	if err := push(uids[0], channel, &proto.Event{Type: proto.EventType_EVT_TEXT}); errorCode(err) != proto.ErrorCode_ERR_NO_CONSUMER {
+		t.Fatalf("push to a disconnected channel: %v, want %v", err, proto.ErrorCode_ERR_NO_CONSUMER)
+	}
+}
+
+func TestAckReceipts(t *testing.T) {
+	channel := new(Channel)
+	for _, receiptTo := range []string{"", "account/a", "", "account/b"} {
+		channel.sent(channel.sequence(&proto.Event{Type: proto.EventType_EVT_TEXT, ReceiptTo: receiptTo}))
+	}
+	if acked := channel.ack(2); len(acked) != 1 || acked[0].ReceiptTo != "account/a" {
+		t.Fatalf("ack 2 returns %v, want the event to account/a", acked)
+	}
+	redeliver, acked := channel.resume(4)
+	if len(redeliver) != 1 || len(acked) != 0 {
+		t.Fatalf("resume from 4 returns %v and %v, want the 4th to redeliver only", redeliver, acked)
+	}
+	if redeliver, acked = channel.resume(5); len(redeliver) != 0 || len(acked) != 1 || acked[0].ReceiptTo != "account/b" {
+		t.Fatalf("resume from 5 returns %v and %v, want the event to account/b acknowledged", redeliver, acked)
+	}
+}
+
+// recorder is an eventSender recording the data of the events sent
+type recorder []string
+
+func (r *recorder) Send(event *proto.Event) error {
+	*r = append(*r, string(event.Data))
+	return nil
+}
+
+func TestDeliveryOrder(t *testing.T) {
+	uids := newTestRegistrar(1)
+	channel := gRegistrar.findChannel(uids[0])
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+	for _, event := range []*proto.Event{
+		{Type: proto.EventType_EVT_TEXT, Data: []byte("a"), Timestamp: now},
+		{Type: proto.EventType_EVT_TEXT, Data: []byte("expired"), Timestamp: now - 1000, TtlMs: 500},
+		{Type: proto.EventType_EVT_TEXT, Data: []byte("typing 1"), Timestamp: now, CollapseKey: "typing"},
+		{Type: proto.EventType_EVT_TEXT, Data: []byte("urgent"), Timestamp: now, Priority: proto.Priority_PRIORITY_HIGH},
+		{Type: proto.EventType_EVT_TEXT, Data: []byte("typing 2"), Timestamp: now + 1, CollapseKey: "typing"},
+		{Type: proto.EventType_EVT_TEXT, Data: []byte("b"), Timestamp: now, TtlMs: 60000},
+	} {
+		if err := push(uids[0], channel, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	gRegistrar.deleteEventQueue(uids[0])
+
+	var sent recorder
+	if err := gRegistrar.stream(context.Background(), uids[0], "test", channel, 0, &sent); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"urgent", "a", "typing 2", "b"}
+	if strings.Join(sent, ",") != strings.Join(want, ",") {
+		t.Fatalf("sent %q, want %q", sent, want)
+	}
+}
+
+func TestKeep(t *testing.T) {
+	uids := newTestRegistrar(1)
+	gMailbox = NewMailbox(memory.NewStore(), time.Hour)
+	defer func() { gMailbox = nil }()
+	channel := gRegistrar.findChannel(uids[0])
+
+	for _, typ := range []proto.EventType{proto.EventType_EVT_HEARTBEAT, proto.EventType_EVT_KICKED, proto.EventType_EVT_RECONNECT} {
+		if gRegistrar.keep(uids[0], channel, &proto.Event{Type: typ}) {
+			t.Errorf("%v is kept in the mailbox, want it dropped with the channel", typ)
+		}
+	}
+	if !gRegistrar.keep(uids[0], channel, &proto.Event{Type: proto.EventType_EVT_TEXT}) {
+		t.Errorf("%v is not kept in the mailbox", proto.EventType_EVT_TEXT)
+	}
+}
+
+// BenchmarkUnicast publishes to random users among benchChannels, whose events are consumed at once
+func BenchmarkUnicast(b *testing.B) {
+	uids := newTestRegistrar(benchChannels)
+	pub := new(Publisher)
+	var next atomic.Uint64
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			uid := uids[next.Inc()*7919%benchChannels]
+			err := pub.Unicast(context.Background(), &proto.UnicastRequest{
+				UserId: uid.UserID,
+				Event:  &proto.Event{Type: proto.EventType_EVT_TEXT, Data: []byte("hello")},
+			}, new(proto.UnicastResponse))
+			if err != nil {
+				b.Fatal(err)
+			}
+			select {
+			case <-gRegistrar.findEventQueue(uid):
+			default:
+			}
+		}
+	})
+}
+
+// BenchmarkConnect connects and disconnects new devices, while benchChannels are connected
+func BenchmarkConnect(b *testing.B) {
+	newTestRegistrar(benchChannels)
+	var next atomic.Uint64
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			header := &proto.Header{UserId: "new" + strconv.FormatUint(next.Inc(), 10)}
+			if err := gRegistrar.Connect(context.Background(), &proto.ConnectRequest{Header: header}, new(proto.ConnectResponse)); err != nil {
+				b.Fatal(err)
+			}
+			if err := gRegistrar.Disconnect(context.Background(), &proto.DisconnectRequest{Header: header}, new(proto.DisconnectResponse)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// BenchmarkHousekeep runs the housekeeping over one inactivity period, while benchChannels are kept alive
+func BenchmarkHousekeep(b *testing.B) {
+	uids := newTestRegistrar(benchChannels)
+	channels := make([]*Channel, len(uids))
+	for i, uid := range uids {
+		channels[i] = gRegistrar.findChannel(uid)
+	}
+	inactivity := InactivityHeartbeats * DefaultHeartbeatInterval
+	ticks := int(inactivity / HousekeepInterval)
+	now := time.Now()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		for _, channel := range channels {
+			// every channel heartbeats once in the period
+			channel.LastHeartbeat = now.Add(inactivity / 2)
+		}
+		b.StartTimer()
+		for tick := 0; tick < ticks; tick++ {
+			now = now.Add(HousekeepInterval)
+			gRegistrar.expire(now)
+		}
+	}
+	b.StopTimer()
+	if n := gRegistrar.size.Load(); n != benchChannels {
+		b.Fatalf("%v channels alive, want %v", n, benchChannels)
+	}
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*ticks), "ns/tick")
+}
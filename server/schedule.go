@@ -0,0 +1,305 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aclisp/sims/proto"
+	pb "github.com/golang/protobuf/proto"
+	"github.com/google/uuid"
+	"github.com/micro/go-micro/v2/auth"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/logger"
+	"github.com/micro/go-micro/v2/metadata"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/go-micro/v2/sync"
+)
+
+const (
+	schedulePrefix = "schedule"
+	// scheduleLeader is the election of the node firing the scheduled deliveries
+	scheduleLeader = MicroServiceName + ".scheduler"
+	// SchedulePollInterval is how often the leader looks for the due deliveries
+	SchedulePollInterval = time.Second
+	originPrefix         = "origin"
+	// originTTL is how long the nodes a scheduled delivery is forwarded to may look up its caller
+	originTTL = time.Minute
+	// metadataOrigin is the key of the caller of a scheduled delivery in the store, which is forwarded with it
+	metadataOrigin = "sims-origin"
+)
+
+// origin is the caller of a scheduled delivery, which is published on its behalf when due
+type origin struct {
+	account   *auth.Account
+	publisher string
+	// key is the caller kept in the store for the remote nodes, if any
+	key string
+}
+
+type originKey struct{}
+
+// withOrigin returns a context publishing on behalf of the caller of schedule
+func withOrigin(ctx context.Context, schedule *proto.Schedule) context.Context {
+	o := &origin{publisher: schedule.Publisher}
+	if schedule.AccountId != "" {
+		o.account = &auth.Account{ID: schedule.AccountId, Scopes: schedule.AccountScopes}
+	}
+	return context.WithValue(ctx, originKey{}, o)
+}
+
+// originOf returns the caller of the scheduled delivery published by ctx, if any
+func originOf(ctx context.Context) (*origin, bool) {
+	o, ok := ctx.Value(originKey{}).(*origin)
+	return o, ok
+}
+
+// forwardedOrigin returns the caller of a scheduled delivery forwarded by another node, if any.
+// The caller is looked up in the store rather than taken from the metadata, which the clients could forge.
+func forwardedOrigin(ctx context.Context) (*origin, bool) {
+	key, ok := metadata.Get(ctx, metadataOrigin)
+	if !ok || !isForwarded(ctx) || gScheduler == nil {
+		return nil, false
+	}
+	records, err := gScheduler.store.Read(storeKey(originPrefix, key))
+	if err != nil || len(records) == 0 {
+		return nil, false
+	}
+	schedule := new(proto.Schedule)
+	if err := pb.Unmarshal(records[0].Value, schedule); err != nil {
+		logger.Errorf("decode origin error: %v", err)
+		return nil, false
+	}
+	return originOf(withOrigin(ctx, schedule))
+}
+
+// scheduled tells if a publishing request should be kept until deliverAt, a unix time in milliseconds
+func scheduled(ctx context.Context, deliverAt int64) bool {
+	return deliverAt > time.Now().UnixNano()/int64(time.Millisecond) && !isForwarded(ctx)
+}
+
+// Scheduler keeps the scheduled deliveries in the store, and publishes them when due.
+// Only the node elected as the leader publishes, so that each delivery is fired once in a cluster.
+// Without an elector, e.g. when the store is shared but the nodes are not elected together, nothing is scheduled.
+type Scheduler struct {
+	store store.Store
+	sync  sync.Sync
+	pub   *Publisher
+	stop  chan struct{}
+}
+
+// NewScheduler creates the scheduler backed by s, electing the leader by sy if not nil, and publishing by pub
+func NewScheduler(s store.Store, sy sync.Sync, pub *Publisher) *Scheduler {
+	return &Scheduler{
+		store: s,
+		sync:  sy,
+		pub:   pub,
+		stop:  make(chan struct{}),
+	}
+}
+
+func (s *Scheduler) key(id string) string {
+	return storeKey(schedulePrefix, id)
+}
+
+// schedule keeps a delivery until it is due, and returns its id. The ids are ordered by the time they are due.
+func (s *Scheduler) schedule(ctx context.Context, account *auth.Account, schedule *proto.Schedule) (string, error) {
+	if s.sync == nil {
+		return "", errors.New(proto.ErrorCode_ERR_UNSPECIFIED.String(), "scheduling needs the nodes sharing the store to be elected by --sync_address", 503)
+	}
+	publisher, err := publisherKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	schedule.Id = fmt.Sprintf("%020d-%s", schedule.DeliverAt, uuid.New().String())
+	schedule.Publisher = publisher
+	if account != nil {
+		schedule.AccountId = account.ID
+		schedule.AccountScopes = account.Scopes
+	}
+	value, err := pb.Marshal(schedule)
+	if err != nil {
+		return "", errorInternal("encode schedule: %v", err)
+	}
+	if err := s.store.Write(&store.Record{Key: s.key(schedule.Id), Value: value}); err != nil {
+		return "", errorInternal("write schedule: %v", err)
+	}
+	return schedule.Id, nil
+}
+
+// cancel deletes a delivery not yet fired, which is only allowed to the account scheduling it
+func (s *Scheduler) cancel(account *auth.Account, id string) error {
+	records, err := s.store.Read(s.key(id))
+	if err == store.ErrNotFound || (err == nil && len(records) == 0) {
+		return errors.NotFound(proto.ErrorCode_ERR_NOT_FOUND.String(), "schedule %q not found", id)
+	}
+	if err != nil {
+		return errorInternal("read schedule: %v", err)
+	}
+	schedule := new(proto.Schedule)
+	if err := pb.Unmarshal(records[0].Value, schedule); err != nil {
+		return errorInternal("decode schedule: %v", err)
+	}
+	if schedule.AccountId != "" && (account == nil || account.ID != schedule.AccountId) {
+		return errorPermissionDenied("schedule %q is not of the caller", id)
+	}
+	if err := s.store.Delete(s.key(id)); err != nil && err != store.ErrNotFound {
+		return errorInternal("delete schedule: %v", err)
+	}
+	return nil
+}
+
+// Start runs for the leadership in the background, and fires the due deliveries while leading
+func (s *Scheduler) Start() {
+	if s.sync == nil {
+		logger.Warn("scheduling is disabled without --sync_address, since the store is shared")
+		return
+	}
+	go s.run()
+}
+
+// Stop resigns the leadership, if any
+func (s *Scheduler) Stop() {
+	close(s.stop)
+}
+
+func (s *Scheduler) run() {
+	for {
+		// blocks until elected
+		leader, err := s.sync.Leader(scheduleLeader)
+		if err != nil {
+			logger.Errorf("elect scheduler error: %v", err)
+			select {
+			case <-s.stop:
+				return
+			case <-time.After(SchedulePollInterval):
+				continue
+			}
+		}
+		logger.Info("leading the scheduler")
+		if !s.lead(leader) {
+			return
+		}
+		logger.Warn("lost the leadership of the scheduler")
+	}
+}
+
+// lead fires the due deliveries until the leadership is lost, or returns false if stopped
+func (s *Scheduler) lead(leader sync.Leader) bool {
+	status := leader.Status()
+	ticker := time.NewTicker(SchedulePollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			if err := leader.Resign(); err != nil {
+				logger.Errorf("resign scheduler error: %v", err)
+			}
+			return false
+		case <-status:
+			return true
+		case now := <-ticker.C:
+			s.fire(now)
+		}
+	}
+}
+
+// fire publishes the deliveries due by now
+func (s *Scheduler) fire(now time.Time) {
+	prefix := storeKey(schedulePrefix) + "/"
+	keys, err := s.store.List(store.ListPrefix(prefix))
+	if err != nil && err != store.ErrNotFound {
+		logger.Errorf("list schedules error: %v", err)
+		return
+	}
+	sort.Strings(keys)
+	due := fmt.Sprintf("%s%020d", prefix, now.UnixNano()/int64(time.Millisecond))
+	for _, key := range keys {
+		if len(key) < len(due) || key[:len(due)] > due {
+			break
+		}
+		records, err := s.store.Read(key)
+		if err != nil || len(records) == 0 {
+			// canceled
+			continue
+		}
+		// delete before publishing, so that it is never fired twice, even if the leadership moves meanwhile
+		if err := s.store.Delete(key); err != nil {
+			logger.Errorf("delete schedule error: %v", err)
+			continue
+		}
+		schedule := new(proto.Schedule)
+		if err := pb.Unmarshal(records[0].Value, schedule); err != nil {
+			logger.Errorf("decode schedule error: %v", err)
+			continue
+		}
+		s.deliver(schedule)
+	}
+}
+
+// share keeps the caller of a scheduled delivery in the store for the remote nodes it is forwarded to,
+// and returns the function deleting it
+func (s *Scheduler) share(o *origin, schedule *proto.Schedule) func() {
+	if o.account == nil || gLocator == nil {
+		return func() {}
+	}
+	value, err := pb.Marshal(&proto.Schedule{
+		AccountId:     schedule.AccountId,
+		AccountScopes: schedule.AccountScopes,
+		Publisher:     schedule.Publisher,
+	})
+	if err != nil {
+		logger.Errorf("encode origin error: %v", err)
+		return func() {}
+	}
+	key := uuid.New().String()
+	if err := s.store.Write(&store.Record{Key: storeKey(originPrefix, key), Value: value, Expiry: originTTL}); err != nil {
+		logger.Errorf("write origin error: %v", err)
+		return func() {}
+	}
+	o.key = key
+	return func() {
+		if err := s.store.Delete(storeKey(originPrefix, key)); err != nil && err != store.ErrNotFound {
+			logger.Errorf("delete origin error: %v", err)
+		}
+	}
+}
+
+// deliver publishes a due delivery on behalf of its caller
+func (s *Scheduler) deliver(schedule *proto.Schedule) {
+	ctx := withOrigin(context.Background(), schedule)
+	o, _ := originOf(ctx)
+	unshare := s.share(o, schedule)
+	defer unshare()
+	switch {
+	case schedule.Unicast != nil:
+		req := schedule.Unicast
+		req.DeliverAt = 0
+		if err := s.pub.Unicast(ctx, req, new(proto.UnicastResponse)); err != nil {
+			logger.Warnf("[%v] scheduled unicast %v error: %v", req.UserId, schedule.Id, err)
+		}
+	case schedule.Multicast != nil:
+		req := schedule.Multicast
+		req.DeliverAt = 0
+		res := new(proto.MulticastResponse)
+		if err := s.pub.Multicast(ctx, req, res); err != nil {
+			logger.Warnf("scheduled multicast %v error: %v", schedule.Id, err)
+		}
+		for u, code := range res.UserErrcode {
+			logger.Debugf("[%v] scheduled multicast %v error: %v", u, schedule.Id, code)
+		}
+	}
+}
+
+// Cancel TODO
+func (pub *Publisher) Cancel(ctx context.Context, req *proto.CancelRequest, res *proto.CancelResponse) error {
+	if gScheduler == nil {
+		return errors.NotFound(proto.ErrorCode_ERR_NOT_FOUND.String(), "schedule %q not found", req.ScheduleId)
+	}
+	account, err := caller(ctx)
+	if err != nil {
+		return err
+	}
+	return gScheduler.cancel(account, req.ScheduleId)
+}
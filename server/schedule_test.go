@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aclisp/sims/proto"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/metadata"
+	"github.com/micro/go-micro/v2/store/memory"
+	syncmemory "github.com/micro/go-micro/v2/sync/memory"
+)
+
+func TestSchedule(t *testing.T) {
+	uids := newTestRegistrar(1)
+	pub := new(Publisher)
+	gScheduler = NewScheduler(memory.NewStore(), syncmemory.NewSync(), pub)
+	defer func() { gScheduler = nil }()
+
+	deliverAt := time.Now().Add(time.Hour)
+	ids := make([]string, 2)
+	for i := range ids {
+		res := new(proto.UnicastResponse)
+		if err := pub.Unicast(context.Background(), &proto.UnicastRequest{
+			UserId:    uids[0].UserID,
+			Event:     &proto.Event{Type: proto.EventType_EVT_TEXT, Data: []byte("later")},
+			DeliverAt: deliverAt.UnixNano() / int64(time.Millisecond),
+		}, res); err != nil {
+			t.Fatal(err)
+		}
+		if res.ScheduleId == "" || res.EventId == "" {
+			t.Fatalf("scheduled %+v, want schedule_id and event_id", res)
+		}
+		ids[i] = res.ScheduleId
+	}
+	if err := pub.Cancel(context.Background(), &proto.CancelRequest{ScheduleId: ids[1]}, new(proto.CancelResponse)); err != nil {
+		t.Fatal(err)
+	}
+	err := pub.Cancel(context.Background(), &proto.CancelRequest{ScheduleId: ids[1]}, new(proto.CancelResponse))
+	if errorCode(err) != proto.ErrorCode_ERR_NOT_FOUND {
+		t.Fatalf("cancel twice: %v, want %v", err, proto.ErrorCode_ERR_NOT_FOUND)
+	}
+
+	queue := gRegistrar.findEventQueue(uids[0])
+	gScheduler.fire(time.Now())
+	if len(queue) != 0 {
+		t.Fatalf("%v events delivered before due, want 0", len(queue))
+	}
+	// the due delivery is fired once, and the canceled one never
+	gScheduler.fire(deliverAt)
+	gScheduler.fire(deliverAt)
+	if len(queue) != 1 {
+		t.Fatalf("%v events delivered when due, want 1", len(queue))
+	}
+}
+
+func TestScheduleOrigin(t *testing.T) {
+	s := memory.NewStore()
+	gScheduler = NewScheduler(s, syncmemory.NewSync(), new(Publisher))
+	gLocator = NewLocator(s)
+	defer func() { gScheduler, gLocator = nil, nil }()
+
+	schedule := &proto.Schedule{AccountId: "alice", AccountScopes: []string{"user"}}
+	o, _ := originOf(withOrigin(context.Background(), schedule))
+	unshare := gScheduler.share(o, schedule)
+	if o.key == "" {
+		t.Fatal("origin not shared")
+	}
+	forwarded := func(key string) context.Context {
+		ctx := metadata.NewContext(context.Background(), metadata.Metadata{metadataForwarded: "node", metadataOrigin: key})
+		return context.WithValue(ctx, forwardedKey{}, "node")
+	}
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{"forwarded", forwarded(o.key), "alice"},
+		{"forged", forwarded("not-shared"), "anonymous"},
+		{"not forwarded", metadata.NewContext(context.Background(), metadata.Metadata{metadataOrigin: o.key}), "anonymous"},
+	}
+	for _, tt := range tests {
+		account, err := caller(tt.ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := accountID(account); got != tt.want {
+			t.Errorf("%v: caller %q, want %q", tt.name, got, tt.want)
+		}
+	}
+	unshare()
+	if account, _ := caller(forwarded(o.key)); account != nil {
+		t.Errorf("caller %q after delivered, want anonymous", account.ID)
+	}
+}
+
+func TestScheduleWithoutElector(t *testing.T) {
+	s := NewScheduler(memory.NewStore(), nil, new(Publisher))
+	_, err := s.schedule(context.Background(), nil, &proto.Schedule{DeliverAt: 1})
+	if e, ok := err.(*errors.Error); !ok || e.Code != 503 {
+		t.Fatalf("schedule without elector: %v, want 503", err)
+	}
+}
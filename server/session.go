@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/aclisp/sims/proto"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/logger"
+)
+
+// session is a Streamer.Session, which serializes the frames sent by the events and the results
+type session struct {
+	lock   sync.Mutex
+	stream proto.Streamer_SessionStream
+}
+
+func errorInvalidFrame(format string, a ...interface{}) error {
+	return errors.BadRequest(proto.ErrorCode_ERR_INVALID_FRAME.String(), format, a...)
+}
+
+func (s *session) reply(res *proto.SessionResponse) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.stream.Send(res)
+}
+
+// Send sends an event of the channel to the client
+func (s *session) Send(event *proto.Event) error {
+	return s.reply(&proto.SessionResponse{
+		Frame: &proto.SessionResponse_Event{Event: event},
+	})
+}
+
+// result sends the result of the client frame id
+func (s *session) result(id string, err error) error {
+	result := new(proto.SessionResult)
+	if err != nil {
+		result.Errcode = errorCode(err)
+		result.Error = err.Error()
+	}
+	return s.reply(&proto.SessionResponse{
+		Id:    id,
+		Frame: &proto.SessionResponse_Result{Result: result},
+	})
+}
+
+// connect registers the channel of the first frame, and subscribes its topics
+func (reg *Registrar) connect(ctx context.Context, s *session, req *proto.SessionRequest) (UniqueID, *proto.Header, error) {
+	connect := req.GetConnect()
+	if connect == nil {
+		return UniqueID{}, nil, errorInvalidFrame("the first frame of a session must be connect")
+	}
+	uid, err := uniqueIDFromHeader(ctx, connect.Header)
+	if err != nil {
+		return UniqueID{}, nil, err
+	}
+	if err := gLimits.limit(ctx, &proto.ConnectRequest{Header: connect.Header}); err != nil {
+		return uid, nil, err
+	}
+	header := &proto.Header{
+		UserId:    uid.UserID,
+		DeviceId:  uid.DeviceID,
+		UserAgent: connect.Header.GetUserAgent(),
+		RequestId: connect.Header.GetRequestId(),
+	}
+	connected := new(proto.ConnectResponse)
+	if err := reg.Connect(ctx, &proto.ConnectRequest{
+		Header:              header,
+		HeartbeatIntervalMs: connect.HeartbeatIntervalMs,
+	}, connected); err != nil {
+		return uid, nil, err
+	}
+	for _, topic := range connect.Topic {
+		if err := reg.Subscribe(ctx, &proto.SubscribeRequest{Header: header, Topic: topic}, new(proto.SubscribeResponse)); err != nil {
+			return uid, nil, err
+		}
+	}
+	if err := s.reply(&proto.SessionResponse{
+		Id:    req.Id,
+		Frame: &proto.SessionResponse_Connected{Connected: connected},
+	}); err != nil {
+		return uid, nil, err
+	}
+	return uid, header, nil
+}
+
+// handle serves a client frame after connect, and tells whether the session is ended by it
+func (reg *Registrar) handle(ctx context.Context, header *proto.Header, req *proto.SessionRequest) (end bool, err error) {
+	switch frame := req.Frame.(type) {
+	case *proto.SessionRequest_Ack:
+		frame.Ack.Header = header
+		return false, reg.Ack(ctx, frame.Ack, new(proto.AckResponse))
+	case *proto.SessionRequest_Send:
+		// the frames are not handled by the server, so not limited by rateLimit
+		if err := gLimits.limit(ctx, frame.Send); err != nil {
+			return false, err
+		}
+		return false, reg.publisher.Unicast(ctx, frame.Send, new(proto.UnicastResponse))
+	case *proto.SessionRequest_Subscribe:
+		frame.Subscribe.Header = header
+		return false, reg.Subscribe(ctx, frame.Subscribe, new(proto.SubscribeResponse))
+	case *proto.SessionRequest_Unsubscribe:
+		frame.Unsubscribe.Header = header
+		return false, reg.Unsubscribe(ctx, frame.Unsubscribe, new(proto.UnsubscribeResponse))
+	case *proto.SessionRequest_Heartbeat:
+		frame.Heartbeat.Header = header
+		return false, reg.Heartbeat(ctx, frame.Heartbeat, new(proto.HeartbeatResponse))
+	case *proto.SessionRequest_Disconnect:
+		frame.Disconnect.Header = header
+		return true, reg.Disconnect(ctx, frame.Disconnect, new(proto.DisconnectResponse))
+	case *proto.SessionRequest_Connect:
+		return false, errorInvalidFrame("the session is already connected")
+	default:
+		return false, errorInvalidFrame("unknown frame %T", req.Frame)
+	}
+}
+
+// Session TODO
+func (reg *Registrar) Session(ctx context.Context, stream proto.Streamer_SessionStream) error {
+	s := &session{stream: stream}
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	uid, header, err := reg.connect(ctx, s, req)
+	if err != nil {
+		return err
+	}
+	trace := header.RequestId
+	channel := reg.findChannel(uid)
+	if channel == nil {
+		return errorNotRegistered(uid)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		// the session ends when the client stops sending
+		defer cancel()
+		for {
+			req, err := stream.Recv()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				logger.Debugf("[%v %v] receive frame error: %v", uid, trace, err)
+				return
+			}
+			end, err := reg.handle(ctx, header, req)
+			if err := s.result(req.Id, err); err != nil {
+				logger.Errorf("[%v %v] send result error: %v", uid, trace, err)
+				return
+			}
+			if end {
+				return
+			}
+		}
+	}()
+	return reg.stream(ctx, uid, trace, channel, req.GetConnect().ResumeFrom, s)
+}
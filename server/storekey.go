@@ -0,0 +1,34 @@
+package main
+
+import (
+	"net/url"
+	"strings"
+)
+
+// emptyKeyPart stands for an empty part of a store key, because some stores clean up empty path elements
+const emptyKeyPart = "~"
+
+// storeKey joins parts into a store key. Each part is escaped, so that it never contains a slash or is empty.
+func storeKey(parts ...string) string {
+	escaped := make([]string, len(parts))
+	for i, part := range parts {
+		if part == "" {
+			escaped[i] = emptyKeyPart
+		} else {
+			escaped[i] = url.PathEscape(part)
+		}
+	}
+	return strings.Join(escaped, "/")
+}
+
+// storeKeyPart reverses the escaping of a part of a store key
+func storeKeyPart(s string) string {
+	if s == emptyKeyPart {
+		return ""
+	}
+	part, err := url.PathUnescape(s)
+	if err != nil {
+		return s
+	}
+	return part
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"sync"
+
+	"github.com/aclisp/sims/proto"
+	pb "github.com/golang/protobuf/proto"
+	"github.com/micro/go-micro/v2/broker"
+	"github.com/micro/go-micro/v2/logger"
+)
+
+// topicPrefix namespaces the broker topics of SIMS
+const topicPrefix = MicroServiceName + ".topic."
+
+// Topics keeps the channels subscribed to each topic on this node. A node subscribes to a broker topic
+// as long as any of its channels is subscribed, so that events published on any node reach every subscriber.
+type Topics struct {
+	broker broker.Broker
+
+	lock          sync.Mutex
+	subscribers   map[string]map[UniqueID]bool // topic => subscribers
+	subscriptions map[string]broker.Subscriber // topic => broker subscription
+	topics        map[UniqueID]map[string]bool // subscriber => topics
+}
+
+// NewTopics creates the topic subscriptions distributed by b
+func NewTopics(b broker.Broker) *Topics {
+	return &Topics{
+		broker:        b,
+		subscribers:   make(map[string]map[UniqueID]bool),
+		subscriptions: make(map[string]broker.Subscriber),
+		topics:        make(map[UniqueID]map[string]bool),
+	}
+}
+
+// Subscribe subscribes uid to topic
+func (t *Topics) Subscribe(uid UniqueID, topic string) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if _, ok := t.subscriptions[topic]; !ok {
+		sub, err := t.broker.Subscribe(topicPrefix+topic, t.handler(topic))
+		if err != nil {
+			return err
+		}
+		t.subscriptions[topic] = sub
+		t.subscribers[topic] = make(map[UniqueID]bool)
+	}
+	t.subscribers[topic][uid] = true
+	if t.topics[uid] == nil {
+		t.topics[uid] = make(map[string]bool)
+	}
+	t.topics[uid][topic] = true
+	return nil
+}
+
+// Unsubscribe unsubscribes uid from topic
+func (t *Topics) Unsubscribe(uid UniqueID, topic string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.unsubscribe(uid, topic)
+}
+
+// UnsubscribeAll unsubscribes uid from all its topics
+func (t *Topics) UnsubscribeAll(uid UniqueID) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for topic := range t.topics[uid] {
+		t.unsubscribe(uid, topic)
+	}
+}
+
+func (t *Topics) unsubscribe(uid UniqueID, topic string) {
+	if topics, ok := t.topics[uid]; ok {
+		delete(topics, topic)
+		if len(topics) == 0 {
+			delete(t.topics, uid)
+		}
+	}
+	subscribers, ok := t.subscribers[topic]
+	if !ok {
+		return
+	}
+	delete(subscribers, uid)
+	if len(subscribers) > 0 {
+		return
+	}
+	delete(t.subscribers, topic)
+	if sub, ok := t.subscriptions[topic]; ok {
+		delete(t.subscriptions, topic)
+		if err := sub.Unsubscribe(); err != nil {
+			logger.Errorf("unsubscribe broker topic %q error: %v", topic, err)
+		}
+	}
+}
+
+// Publish publishes event to the subscribers of topic on all nodes
+func (t *Topics) Publish(topic string, event *proto.Event) error {
+	body, err := pb.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return t.broker.Publish(topicPrefix+topic, &broker.Message{
+		Header: map[string]string{"Content-Type": "application/protobuf"},
+		Body:   body,
+	})
+}
+
+// handler delivers the events from the broker to the channels subscribed to topic on this node
+func (t *Topics) handler(topic string) broker.Handler {
+	return func(e broker.Event) error {
+		event := new(proto.Event)
+		if err := pb.Unmarshal(e.Message().Body, event); err != nil {
+			logger.Errorf("decode event of topic %q error: %v", topic, err)
+			return err
+		}
+		t.lock.Lock()
+		subscribers := make([]UniqueID, 0, len(t.subscribers[topic]))
+		for uid := range t.subscribers[topic] {
+			subscribers = append(subscribers, uid)
+		}
+		t.lock.Unlock()
+		for _, uid := range subscribers {
+			channel := gRegistrar.findChannel(uid)
+			if channel == nil {
+				continue
+			}
+			if err := push(uid, channel, event); err != nil {
+				logger.Warnf("[%v] deliver event of topic %q error: %v", uid, topic, err)
+			}
+		}
+		return nil
+	}
+}
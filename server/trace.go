@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/aclisp/sims/proto"
+	"github.com/micro/go-micro/v2/client"
+	debugproto "github.com/micro/go-micro/v2/debug/service/proto"
+	"github.com/micro/go-micro/v2/debug/trace"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/logger"
+)
+
+// traceEvent sets the trace of event to the publishing request of ctx, so that its delivery is traced along.
+// The trace set by a client is dropped, except that a scheduled delivery keeps the trace of scheduling it.
+func traceEvent(ctx context.Context, event *proto.Event) {
+	if traceID, spanID, ok := trace.FromContext(ctx); ok {
+		event.TraceId, event.SpanId = traceID, spanID
+		return
+	}
+	if _, ok := originOf(ctx); !ok {
+		event.TraceId, event.SpanId = "", ""
+	}
+}
+
+// traceSpan records a span of event on its trace, from started until now. Untraced events are not recorded.
+func traceSpan(name string, uid UniqueID, event *proto.Event, started time.Time, err error) {
+	if event.TraceId == "" {
+		return
+	}
+	tracer := trace.DefaultTracer
+	_, span := tracer.Start(trace.ToContext(context.Background(), event.TraceId, event.SpanId), name)
+	if span == nil {
+		return
+	}
+	span.Started = started
+	span.Metadata["event_id"] = event.Id
+	span.Metadata["user_id"] = uid.UserID
+	if uid.DeviceID != "" {
+		span.Metadata["device_id"] = uid.DeviceID
+	}
+	if event.Seq > 0 {
+		span.Metadata["seq"] = strconv.FormatUint(event.Seq, 10)
+	}
+	if err != nil {
+		span.Metadata["error"] = err.Error()
+	}
+	tracer.Finish(span)
+}
+
+// Trace TODO
+func (a *Admin) Trace(ctx context.Context, req *proto.TraceRequest, res *proto.TraceResponse) error {
+	if err := allowAdmin(ctx); err != nil {
+		return err
+	}
+	debug := debugproto.NewDebugService(MicroServiceName, gService.Client())
+	for _, node := range append([]string{gAddress}, otherNodes()...) {
+		rsp, err := debug.Trace(ctx, &debugproto.TraceRequest{Id: req.TraceId}, client.WithAddress(node))
+		if err != nil {
+			logger.Warnf("read trace %v of %v error: %v", req.TraceId, node, err)
+			continue
+		}
+		for _, span := range rsp.Spans {
+			res.Spans = append(res.Spans, &proto.Span{
+				Trace:    span.Trace,
+				Id:       span.Id,
+				Parent:   span.Parent,
+				Name:     span.Name,
+				Started:  int64(span.Started),
+				Duration: int64(span.Duration),
+				Node:     node,
+				Metadata: span.Metadata,
+			})
+		}
+	}
+	if len(res.Spans) == 0 {
+		return errors.NotFound(proto.ErrorCode_ERR_NOT_FOUND.String(), "trace %q not found", req.TraceId)
+	}
+	res.Spans = orderSpans(res.Spans)
+	return nil
+}
+
+// orderSpans orders spans by their start time, except that a span follows its parent.
+// The start of event delivery is the timestamp of the event, which is truncated to milliseconds.
+func orderSpans(spans []*proto.Span) []*proto.Span {
+	sort.SliceStable(spans, func(i, j int) bool { return spans[i].Started < spans[j].Started })
+	ids := make(map[string]bool, len(spans))
+	children := make(map[string][]*proto.Span)
+	for _, span := range spans {
+		ids[span.Id] = true
+		children[span.Parent] = append(children[span.Parent], span)
+	}
+	ordered := make([]*proto.Span, 0, len(spans))
+	var visit func(span *proto.Span)
+	visit = func(span *proto.Span) {
+		ordered = append(ordered, span)
+		for _, child := range children[span.Id] {
+			visit(child)
+		}
+	}
+	for _, span := range spans {
+		if !ids[span.Parent] {
+			visit(span)
+		}
+	}
+	return ordered
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aclisp/sims/proto"
+	"github.com/micro/go-micro/v2/debug/trace"
+	"github.com/micro/go-micro/v2/debug/trace/memory"
+)
+
+func TestTraceDelivery(t *testing.T) {
+	uids := newTestRegistrar(1)
+	channel := gRegistrar.findChannel(uids[0])
+	tracer := trace.DefaultTracer
+	trace.DefaultTracer = memory.NewTracer()
+	defer func() { trace.DefaultTracer = tracer }()
+
+	ctx, span := trace.DefaultTracer.Start(context.Background(), "Publisher.Unicast")
+	res := new(proto.UnicastResponse)
+	if err := new(Publisher).Unicast(ctx, &proto.UnicastRequest{
+		UserId: uids[0].UserID,
+		Event:  &proto.Event{Type: proto.EventType_EVT_TEXT, TraceId: "spoofed"},
+	}, res); err != nil {
+		t.Fatal(err)
+	}
+	trace.DefaultTracer.Finish(span)
+	if res.TraceId != span.Trace {
+		t.Fatalf("traced by %q, want %q", res.TraceId, span.Trace)
+	}
+	gRegistrar.deleteEventQueue(uids[0])
+	if err := gRegistrar.stream(context.Background(), uids[0], "test", channel, 0, new(recorder)); err != nil {
+		t.Fatal(err)
+	}
+
+	spans, _ := trace.DefaultTracer.Read(trace.ReadTrace(span.Trace))
+	ordered := make([]*proto.Span, len(spans))
+	for i, s := range spans {
+		ordered[i] = &proto.Span{Id: s.Id, Parent: s.Parent, Name: s.Name, Started: s.Started.UnixNano()}
+	}
+	ordered = orderSpans(ordered)
+	if len(ordered) != 2 || ordered[0].Name != "Publisher.Unicast" || ordered[1].Name != "sims.send" || ordered[1].Parent != span.Id {
+		t.Fatalf("traced %v, want the send following the publishing", ordered)
+	}
+}
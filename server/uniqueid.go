@@ -1,24 +1,47 @@
 package main
 
 import (
+	"context"
+
 	"github.com/aclisp/sims/proto"
 	"github.com/micro/go-micro/v2/errors"
 )
 
 // UniqueID TODO
 type UniqueID struct {
-	UserID string
+	UserID   string
+	DeviceID string
+}
+
+func (uid UniqueID) String() string {
+	if uid.DeviceID == "" {
+		return uid.UserID
+	}
+	return uid.UserID + "/" + uid.DeviceID
 }
 
-func uniqueIDFromHeader(header *proto.Header) (UniqueID, error) {
+// uniqueIDFromHeader returns the UniqueID of a client. If authentication is enabled, the user_id is the
+// authenticated one, which the header should either omit or agree with.
+func uniqueIDFromHeader(ctx context.Context, header *proto.Header) (UniqueID, error) {
 	if header == nil {
 		return UniqueID{}, errors.BadRequest(proto.ErrorCode_ERR_MISSING_HEADER.String(), "")
 	}
+	account, err := authenticate(ctx)
+	if err != nil {
+		return UniqueID{}, err
+	}
 	userID := header.GetUserId()
+	if account != nil {
+		if userID != "" && userID != account.ID {
+			return UniqueID{}, errorUnauthenticated("token is issued to %q, not %q", account.ID, userID)
+		}
+		userID = account.ID
+	}
 	if userID == "" {
 		return UniqueID{}, errors.BadRequest(proto.ErrorCode_ERR_MISSING_USERID.String(), "")
 	}
 	return UniqueID{
-		UserID: userID,
+		UserID:   userID,
+		DeviceID: header.GetDeviceId(),
 	}, nil
 }
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// wheelSlots is the number of slots of a timingWheel, which covers the longest inactivity in one round
+const wheelSlots = 512
+
+// wheelEntry is a channel scheduled to be checked for expiry
+type wheelEntry struct {
+	channel *Channel
+	at      int64 // the tick when the entry is due
+}
+
+// timingWheel schedules the expiry checks of channels in slots of a tick, so that housekeeping visits
+// only the channels due instead of all. Heartbeats do not touch the wheel: an entry found alive when it
+// is due is scheduled again by its last heartbeat.
+type timingWheel struct {
+	lock  sync.Mutex
+	tick  time.Duration
+	slots [wheelSlots][]wheelEntry
+	last  int64 // the last tick advanced to
+}
+
+func newTimingWheel(tick time.Duration, now time.Time) *timingWheel {
+	return &timingWheel{
+		tick: tick,
+		last: now.UnixNano() / int64(tick),
+	}
+}
+
+// tickOf returns the tick of t
+func (w *timingWheel) tickOf(t time.Time) int64 {
+	return t.UnixNano() / int64(w.tick)
+}
+
+// add puts e into its slot, or the next one if its tick has passed. Must be called with lock held.
+func (w *timingWheel) add(e wheelEntry) {
+	if e.at <= w.last {
+		e.at = w.last + 1
+	}
+	slot := &w.slots[e.at%wheelSlots]
+	*slot = append(*slot, e)
+}
+
+// schedule makes channel due at the tick of t
+func (w *timingWheel) schedule(channel *Channel, t time.Time) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.add(wheelEntry{channel: channel, at: w.tickOf(t)})
+}
+
+// reschedule puts the entries at their ticks again
+func (w *timingWheel) reschedule(entries []wheelEntry) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	for _, e := range entries {
+		w.add(e)
+	}
+}
+
+// advance moves the wheel to now, and returns the entries due
+func (w *timingWheel) advance(now time.Time) []wheelEntry {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	to := w.tickOf(now)
+	from := w.last + 1
+	if to-from >= wheelSlots {
+		// every slot is visited once after a long pause
+		from = to - wheelSlots + 1
+	}
+	var due []wheelEntry
+	for tick := from; tick <= to; tick++ {
+		slot := &w.slots[tick%wheelSlots]
+		kept := (*slot)[:0]
+		for _, e := range *slot {
+			if e.at <= to {
+				due = append(due, e)
+			} else {
+				kept = append(kept, e)
+			}
+		}
+		// clear the tail, so that the removed channels can be collected
+		for i := len(kept); i < len(*slot); i++ {
+			(*slot)[i] = wheelEntry{}
+		}
+		*slot = kept
+	}
+	if to > w.last {
+		w.last = to
+	}
+	return due
+}